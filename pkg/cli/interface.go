@@ -48,6 +48,10 @@ type Params interface {
 	Clients() (*Clients, error)
 	KubeClient() (k8s.Interface, error)
 
+	// KubeUser returns the name of the user of the kubeconfig context in
+	// use, or an empty string when it cannot be determined
+	KubeUser() string
+
 	// SetNamespace can be used to store the namespace parameter that is needed
 	// by most commands
 	SetNamespace(string)
@@ -123,7 +123,7 @@ func (p *TektonParams) Clients() (*Clients, error) {
 	return p.clients, nil
 }
 
-func (p *TektonParams) config() (*rest.Config, error) {
+func (p *TektonParams) kubeConfig() clientcmd.ClientConfig {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if p.kubeConfigPath != "" {
 		loadingRules.ExplicitPath = p.kubeConfigPath
@@ -132,7 +132,11 @@ func (p *TektonParams) config() (*rest.Config, error) {
 	if p.kubeContext != "" {
 		configOverrides.CurrentContext = p.kubeContext
 	}
-	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+}
+
+func (p *TektonParams) config() (*rest.Config, error) {
+	kubeConfig := p.kubeConfig()
 	if p.namespace == "" {
 		namespace, _, err := kubeConfig.Namespace()
 		if err != nil {
@@ -147,6 +151,24 @@ func (p *TektonParams) config() (*rest.Config, error) {
 	return config, nil
 }
 
+func (p *TektonParams) KubeUser() string {
+	raw, err := p.kubeConfig().RawConfig()
+	if err != nil {
+		return ""
+	}
+
+	current := raw.CurrentContext
+	if p.kubeContext != "" {
+		current = p.kubeContext
+	}
+
+	ctx, ok := raw.Contexts[current]
+	if !ok {
+		return ""
+	}
+	return ctx.AuthInfo
+}
+
 func (p *TektonParams) SetNoColour(b bool) {
 	color.NoColor = b
 }
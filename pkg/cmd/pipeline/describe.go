@@ -17,17 +17,22 @@ package pipeline
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/cli/pkg/printer"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const describeTemplate = `Name:	{{ .PipelineName }}
@@ -61,12 +66,28 @@ NAME	TYPE	DEFAULT VALUE
 {{- end }}
 
 Tasks
-{{- $tl := len .Pipeline.Spec.Tasks }}{{ if eq $tl 0 }}
+{{- $tl := len .Tasks }}{{ if eq $tl 0 }}
 No tasks
 {{- else }}
-NAME	TASKREF	RUNAFTER
-{{- range $i, $t := .Pipeline.Spec.Tasks }}
-{{ $t.Name }}	{{ $t.TaskRef.Name }}	{{ $t.RunAfter }}
+NAME	TASKREF	KIND	RUNAFTER	CONDITIONS	RETRIES
+{{- range $i, $t := .Tasks }}
+{{ $t.Name }}	{{ $t.TaskRef }}	{{ $t.Kind }}	{{ $t.RunAfter }}	{{ $t.Conditions }}	{{ $t.Retries }}
+{{- end }}
+{{- end }}
+{{- if ne (len .TaskParams) 0 }}
+
+Task Params
+TASK	NAME	VALUE	PIPELINE PARAMS
+{{- range $i, $p := .TaskParams }}
+{{ $p.Task }}	{{ $p.Name }}	{{ $p.Value }}	{{ $p.PipelineParams }}
+{{- end }}
+{{- end }}
+{{- if ne (len .TaskResources) 0 }}
+
+Task Resources
+TASK	DIRECTION	NAME	RESOURCE	FROM
+{{- range $i, $r := .TaskResources }}
+{{ $r.Task }}	{{ $r.Direction }}	{{ $r.Name }}	{{ $r.Resource }}	{{ $r.From }}
 {{- end }}
 {{- end }}
 
@@ -82,7 +103,7 @@ NAME	STARTED	DURATION	STATUS
 `
 
 func describeCommand(p cli.Params) *cobra.Command {
-	f := cliopts.NewPrintFlags("describe")
+	opts := options.NewDescribeOptions()
 
 	c := &cobra.Command{
 		Use:     "describe",
@@ -99,16 +120,16 @@ func describeCommand(p cli.Params) *cobra.Command {
 				return err
 			}
 
-			return printPipelineDescription(cmd.OutOrStdout(), p, args[0])
+			return printPipelineDescription(cmd.OutOrStdout(), p, args[0], opts)
 		},
 	}
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
-	f.AddFlags(c)
+	opts.AddFlags(c)
 	return c
 }
 
-func printPipelineDescription(out io.Writer, p cli.Params, pname string) error {
+func printPipelineDescription(out io.Writer, p cli.Params, pname string, opts *options.DescribeOptions) error {
 	cs, err := p.Clients()
 	if err != nil {
 		return err
@@ -119,28 +140,45 @@ func printPipelineDescription(out io.Writer, p cli.Params, pname string) error {
 		return err
 	}
 
+	if opts.Output() != "" {
+		// NOTE: this is required for -o json|yaml to work properly since
+		// tektoncd go client fails to set these; probably a bug
+		pipeline.GetObjectKind().SetGroupVersionKind(
+			schema.GroupVersionKind{
+				Version: "tekton.dev/v1alpha1",
+				Kind:    "Pipeline",
+			})
+		return printer.PrintObject(out, pipeline, opts.PrintFlags)
+	}
+
 	if len(pipeline.Spec.Resources) > 0 {
 		pipeline.Spec.Resources = sortResourcesByTypeAndName(pipeline.Spec.Resources)
 	}
 
-	opts := metav1.ListOptions{
+	lOpts := metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("tekton.dev/pipeline=%s", pname),
 	}
-	pipelineRuns, err := cs.Tekton.TektonV1alpha1().PipelineRuns(p.Namespace()).List(opts)
+	pipelineRuns, err := cs.Tekton.TektonV1alpha1().PipelineRuns(p.Namespace()).List(lOpts)
 	if err != nil {
 		return err
 	}
 
 	var data = struct {
-		Pipeline     *v1alpha1.Pipeline
-		PipelineRuns *v1alpha1.PipelineRunList
-		PipelineName string
-		Params       cli.Params
+		Pipeline      *v1alpha1.Pipeline
+		PipelineRuns  *v1alpha1.PipelineRunList
+		PipelineName  string
+		Params        cli.Params
+		Tasks         []pipelineTask
+		TaskParams    []pipelineTaskParam
+		TaskResources []pipelineTaskResource
 	}{
-		Pipeline:     pipeline,
-		PipelineRuns: pipelineRuns,
-		PipelineName: pname,
-		Params:       p,
+		Pipeline:      pipeline,
+		PipelineRuns:  pipelineRuns,
+		PipelineName:  pname,
+		Params:        p,
+		Tasks:         pipelineTasks(cs, p.Namespace(), pipeline.Spec.Tasks),
+		TaskParams:    pipelineTaskParams(pipeline.Spec.Tasks),
+		TaskResources: pipelineTaskResources(pipeline.Spec.Tasks),
 	}
 
 	funcMap := template.FuncMap{
@@ -149,8 +187,12 @@ func printPipelineDescription(out io.Writer, p cli.Params, pname string) error {
 		"formatCondition": formatted.Condition,
 	}
 
+	t, err := opts.Template("Describe Pipeline", describeTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+
 	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("Describe Pipeline").Funcs(funcMap).Parse(describeTemplate))
 	err = t.Execute(w, data)
 	if err != nil {
 		return err
@@ -175,3 +217,156 @@ func sortResourcesByTypeAndName(pres []v1alpha1.PipelineDeclaredResource) []v1al
 
 	return pres
 }
+
+// pipelineTask is a task of the pipeline, with the names of its task and
+// conditions marked when they do not exist
+type pipelineTask struct {
+	Name       string
+	TaskRef    string
+	Kind       string
+	RunAfter   string
+	Conditions string
+	Retries    int
+}
+
+// pipelineTaskParam is a param given to a task of the pipeline, along with
+// the params of the pipeline its value refers to
+type pipelineTaskParam struct {
+	Task           string
+	Name           string
+	Value          string
+	PipelineParams string
+}
+
+// pipelineTaskResource is a resource of the pipeline given to a task, which
+// comes from other tasks when From is set
+type pipelineTaskResource struct {
+	Task      string
+	Direction string
+	Name      string
+	Resource  string
+	From      string
+}
+
+const missingMarker = " (missing)"
+
+var pipelineParamRef = regexp.MustCompile(`\$\(params\.([^)]+)\)`)
+
+func pipelineTasks(cs *cli.Clients, ns string, tasks []v1alpha1.PipelineTask) []pipelineTask {
+	found := map[string]bool{}
+	exists := func(kind, name string, get func() error) bool {
+		key := kind + "/" + name
+		if f, ok := found[key]; ok {
+			return f
+		}
+		// only the objects the api server does not know are missing, the
+		// other errors do not tell whether they exist
+		err := get()
+		found[key] = !errors.IsNotFound(err)
+		return found[key]
+	}
+
+	pts := []pipelineTask{}
+	for _, t := range tasks {
+		kind := t.TaskRef.Kind
+		if kind == "" {
+			kind = v1alpha1.NamespacedTaskKind
+		}
+
+		ref := t.TaskRef.Name
+		taskExists := exists(string(kind), ref, func() error {
+			if kind == v1alpha1.ClusterTaskKind {
+				_, err := cs.Tekton.TektonV1alpha1().ClusterTasks().Get(ref, metav1.GetOptions{})
+				return err
+			}
+			_, err := cs.Tekton.TektonV1alpha1().Tasks(ns).Get(ref, metav1.GetOptions{})
+			return err
+		})
+		if !taskExists {
+			ref += missingMarker
+		}
+
+		conditions := []string{}
+		for _, c := range t.Conditions {
+			name := c.ConditionRef
+			if !exists("Condition", name, func() error {
+				_, err := cs.Tekton.TektonV1alpha1().Conditions(ns).Get(name, metav1.GetOptions{})
+				return err
+			}) {
+				name += missingMarker
+			}
+			conditions = append(conditions, name)
+		}
+
+		pts = append(pts, pipelineTask{
+			Name:       t.Name,
+			TaskRef:    ref,
+			Kind:       string(kind),
+			RunAfter:   joinOrNone(t.RunAfter),
+			Conditions: joinOrNone(conditions),
+			Retries:    t.Retries,
+		})
+	}
+	return pts
+}
+
+func pipelineTaskParams(tasks []v1alpha1.PipelineTask) []pipelineTaskParam {
+	params := []pipelineTaskParam{}
+	for _, t := range tasks {
+		for _, p := range t.Params {
+			value := p.Value.StringVal
+			if p.Value.Type == v1alpha1.ParamTypeArray {
+				value = fmt.Sprintf("%v", p.Value.ArrayVal)
+			}
+
+			refs := []string{}
+			for _, m := range pipelineParamRef.FindAllStringSubmatch(value, -1) {
+				refs = append(refs, m[1])
+			}
+
+			params = append(params, pipelineTaskParam{
+				Task:           t.Name,
+				Name:           p.Name,
+				Value:          value,
+				PipelineParams: joinOrNone(refs),
+			})
+		}
+	}
+	return params
+}
+
+func pipelineTaskResources(tasks []v1alpha1.PipelineTask) []pipelineTaskResource {
+	resources := []pipelineTaskResource{}
+	for _, t := range tasks {
+		if t.Resources == nil {
+			continue
+		}
+
+		for _, in := range t.Resources.Inputs {
+			resources = append(resources, pipelineTaskResource{
+				Task:      t.Name,
+				Direction: "input",
+				Name:      in.Name,
+				Resource:  in.Resource,
+				From:      joinOrNone(in.From),
+			})
+		}
+		for _, out := range t.Resources.Outputs {
+			resources = append(resources, pipelineTaskResource{
+				Task:      t.Name,
+				Direction: "output",
+				Name:      out.Name,
+				Resource:  out.Resource,
+				From:      "---",
+			})
+		}
+	}
+	return resources
+}
+
+func joinOrNone(s []string) string {
+	if len(s) == 0 {
+		return "---"
+	}
+	return strings.Join(s, ", ")
+}
@@ -243,8 +243,8 @@ func TestPipelinesDescribe_with_task_run(t *testing.T) {
 		"Params",
 		"No params\n",
 		"Tasks",
-		"NAME   TASKREF   RUNAFTER",
-		"task   taskref   [one two]\n",
+		"NAME   TASKREF             KIND   RUNAFTER   CONDITIONS   RETRIES",
+		"task   taskref (missing)   Task   one, two   ---          0\n",
 		"Pipelineruns",
 		"NAME             STARTED          DURATION     STATUS",
 		"pipeline-run-1   15 minutes ago   10 minutes   Succeeded\n",
@@ -323,8 +323,8 @@ func TestPipelinesDescribe_with_resource_param_task_run(t *testing.T) {
 		"NAME             TYPE     DEFAULT VALUE",
 		"pipeline-param   string   somethingdifferent\n",
 		"Tasks",
-		"NAME   TASKREF   RUNAFTER",
-		"task   taskref   [one two]\n",
+		"NAME   TASKREF             KIND   RUNAFTER   CONDITIONS   RETRIES",
+		"task   taskref (missing)   Task   one, two   ---          0\n",
 		"Pipelineruns",
 		"NAME             STARTED          DURATION     STATUS",
 		"pipeline-run-1   15 minutes ago   10 minutes   Succeeded\n",
@@ -417,8 +417,8 @@ func TestPipelinesDescribe_with_multiple_resource_param_task_run(t *testing.T) {
 		"pipeline-param2   string   ",
 		"rev-param2        array    \n",
 		"Tasks",
-		"NAME   TASKREF   RUNAFTER",
-		"task   taskref   [one two]\n",
+		"NAME   TASKREF             KIND   RUNAFTER   CONDITIONS   RETRIES",
+		"task   taskref (missing)   Task   one, two   ---          0\n",
 		"Pipelineruns",
 		"NAME             STARTED          DURATION     STATUS",
 		"pipeline-run-1   15 minutes ago   10 minutes   Succeeded\n",
@@ -429,3 +429,120 @@ func TestPipelinesDescribe_with_multiple_resource_param_task_run(t *testing.T) {
 		t.Errorf("Unexpected output mismatch: \n%s\n", d)
 	}
 }
+
+func TestPipelinesDescribe_output(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Pipelines: []*v1alpha1.Pipeline{
+			tb.Pipeline("pipeline", "ns",
+				tb.PipelineSpec(
+					tb.PipelineTask("build", "build-task"),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	pipeline := Command(p)
+
+	got, err := test.ExecuteCommand(pipeline, "desc", "-n", "ns", "pipeline", "-o", "jsonpath={.kind} {.spec.tasks[0].taskRef.name}")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "Pipeline build-task", got)
+}
+
+func TestPipelinesDescribe_task_details(t *testing.T) {
+	clusterTask := func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef.Kind = v1alpha1.ClusterTaskKind
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Pipelines: []*v1alpha1.Pipeline{
+			tb.Pipeline("pipeline", "ns",
+				tb.PipelineSpec(
+					tb.PipelineDeclaredResource("source", v1alpha1.PipelineResourceTypeGit),
+					tb.PipelineDeclaredResource("image", v1alpha1.PipelineResourceTypeImage),
+					tb.PipelineParamSpec("revision", v1alpha1.ParamTypeString),
+					tb.PipelineTask("build", "build-task",
+						tb.PipelineTaskParam("revision", "$(params.revision)"),
+						tb.PipelineTaskParam("flags", "-v", "--tags=$(params.tags)"),
+						tb.PipelineTaskInputResource("source", "source"),
+						tb.PipelineTaskOutputResource("image", "image"),
+					),
+					tb.PipelineTask("lint", "golangci", clusterTask),
+					tb.PipelineTask("deploy", "kubectl", clusterTask,
+						tb.RunAfter("lint"),
+						tb.Retries(3),
+						tb.PipelineTaskCondition("on-main"),
+						tb.PipelineTaskCondition("approved"),
+						tb.PipelineTaskInputResource("image", "image", tb.From("build")),
+					),
+				),
+			),
+		},
+		Tasks: []*v1alpha1.Task{
+			tb.Task("build-task", "ns"),
+		},
+		ClusterTasks: []*v1alpha1.ClusterTask{
+			tb.ClusterTask("golangci"),
+		},
+		Conditions: []*v1alpha1.Condition{
+			tb.Condition("on-main", "ns"),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	pipeline := Command(p)
+
+	got, err := test.ExecuteCommand(pipeline, "desc", "-n", "ns", "pipeline")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	expected := `Name:   pipeline
+
+Resources
+NAME     TYPE
+source   git
+image    image
+
+Params
+NAME       TYPE     DEFAULT VALUE
+revision   string   
+
+Tasks
+NAME     TASKREF             KIND          RUNAFTER   CONDITIONS                    RETRIES
+build    build-task          Task          ---        ---                           0
+lint     golangci            ClusterTask   ---        ---                           0
+deploy   kubectl (missing)   ClusterTask   lint       on-main, approved (missing)   3
+
+Task Params
+TASK    NAME       VALUE                        PIPELINE PARAMS
+build   revision   $(params.revision)           revision
+build   flags      [-v --tags=$(params.tags)]   tags
+
+Task Resources
+TASK     DIRECTION   NAME     RESOURCE   FROM
+build    input       source   source     ---
+build    output      image    image      ---
+deploy   input       image    image      build
+
+Pipelineruns
+No pipelineruns
+`
+	test.AssertOutput(t, expected, got)
+}
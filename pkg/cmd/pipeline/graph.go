@@ -0,0 +1,569 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/formatted"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+type graphOptions struct {
+	from        string
+	output      string
+	pipelineRun string
+}
+
+// graphNode is a task of the pipeline, or a condition gating a task
+type graphNode struct {
+	id         string
+	name       string
+	kind       string
+	taskRef    string
+	retries    int
+	conditions []*graphNode
+	status     duckv1beta1.Conditions
+	skipped    bool
+	// missing is set on the tasks the edges come from which are not tasks
+	// of the pipeline
+	missing bool
+}
+
+// graphEdge tells that a task runs after another, passing it resources when
+// they come from the task
+type graphEdge struct {
+	from      string
+	to        string
+	resources []string
+}
+
+// graph is the DAG of the tasks of a pipeline
+type graph struct {
+	name    string
+	nodes   []*graphNode
+	edges   []*graphEdge
+	missing []*graphNode
+}
+
+func graphCommand(p cli.Params) *cobra.Command {
+	opts := &graphOptions{output: "ascii"}
+	eg := `Show the tasks of the Pipeline named 'foo' from namespace 'bar' and the order they run in:
+
+    tkn pipeline graph foo -n bar
+
+Render the Pipeline defined by foo.yaml with Graphviz:
+
+    tkn pipeline graph -f foo.yaml -o dot | dot -Tpng > foo.png
+
+Show the status of each task of the PipelineRun named 'foo-run-1' as a Mermaid graph:
+
+    tkn pipeline graph --pipelinerun foo-run-1 -o mermaid -n bar
+`
+
+	c := &cobra.Command{
+		Use:   "graph",
+		Short: "Show the graph of the tasks of a pipeline",
+		Long: `Show the graph of the tasks of a pipeline.
+
+The edges of the graph come from the runAfter of the tasks and from the
+resources they take from other tasks. The conditions of a task are shown as
+gates between the task and the tasks it runs after. When a pipelinerun is
+given, the tasks are colored after the status of their taskruns.`,
+		Example:      eg,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			switch opts.output {
+			case "ascii", "dot", "mermaid":
+			default:
+				return fmt.Errorf("output format %q is not supported, use ascii, dot or mermaid", opts.output)
+			}
+
+			if len(args) != 0 && opts.from != "" {
+				return fmt.Errorf("--from cannot be used with the name of a pipeline")
+			}
+			if len(args) == 0 && opts.from == "" && opts.pipelineRun == "" {
+				return fmt.Errorf("pipeline name required, or use --from or --pipelinerun")
+			}
+
+			if opts.from == "" {
+				if err := validate.NamespaceExists(p); err != nil {
+					return err
+				}
+			}
+
+			name := ""
+			if len(args) != 0 {
+				name = args[0]
+			}
+			return printGraph(s, p, name, opts)
+		},
+	}
+
+	c.Flags().StringVarP(&opts.from, "from", "f", "", "local or remote filename of the pipeline")
+	c.Flags().StringVarP(&opts.output, "output", "o", opts.output, "format of the graph: ascii, dot or mermaid")
+	c.Flags().StringVarP(&opts.pipelineRun, "pipelinerun", "", "", "name of a pipelinerun whose status colors the tasks")
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
+	return c
+}
+
+func printGraph(s *cli.Stream, p cli.Params, name string, opts *graphOptions) error {
+	var pr *v1alpha1.PipelineRun
+	if opts.pipelineRun != "" {
+		cs, err := p.Clients()
+		if err != nil {
+			return fmt.Errorf("failed to create tekton client")
+		}
+
+		pr, err = cs.Tekton.TektonV1alpha1().PipelineRuns(p.Namespace()).Get(opts.pipelineRun, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to find pipelinerun %q", opts.pipelineRun)
+		}
+	}
+
+	name, spec, err := graphPipeline(p, name, opts.from, pr)
+	if err != nil {
+		return err
+	}
+
+	g := newGraph(name, spec)
+	if pr != nil {
+		g.setStatus(pr)
+	}
+
+	switch opts.output {
+	case "dot":
+		g.dot(s.Out)
+	case "mermaid":
+		g.mermaid(s.Out)
+	default:
+		g.ascii(s.Out)
+	}
+	return nil
+}
+
+// graphPipeline returns the name and the spec of the pipeline to draw, from
+// its name, its file or the pipelinerun
+func graphPipeline(p cli.Params, name, from string, pr *v1alpha1.PipelineRun) (string, *v1alpha1.PipelineSpec, error) {
+	if from != "" {
+		pipeline, err := loadPipeline(p, from)
+		if err != nil {
+			return "", nil, err
+		}
+		return pipeline.Name, &pipeline.Spec, nil
+	}
+
+	if name == "" {
+		if pr.Spec.PipelineSpec != nil {
+			return pr.Name, pr.Spec.PipelineSpec, nil
+		}
+		if pr.Spec.PipelineRef == nil {
+			return "", nil, fmt.Errorf("pipelinerun %s has no pipeline", pr.Name)
+		}
+		name = pr.Spec.PipelineRef.Name
+	}
+
+	cs, err := p.Clients()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create tekton client")
+	}
+
+	pipeline, err := cs.Tekton.TektonV1alpha1().Pipelines(p.Namespace()).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf(errInvalidPipeline, name, p.Namespace())
+	}
+	return pipeline.Name, &pipeline.Spec, nil
+}
+
+func newGraph(name string, spec *v1alpha1.PipelineSpec) *graph {
+	g := &graph{name: name}
+
+	for _, t := range spec.Tasks {
+		kind := string(t.TaskRef.Kind)
+		if kind == "" {
+			kind = string(v1alpha1.NamespacedTaskKind)
+		}
+
+		n := &graphNode{
+			id:      t.Name,
+			name:    t.Name,
+			kind:    kind,
+			taskRef: t.TaskRef.Name,
+			retries: t.Retries,
+		}
+		for i, c := range t.Conditions {
+			n.conditions = append(n.conditions, &graphNode{
+				id:   fmt.Sprintf("%s.%d", t.Name, i),
+				name: c.ConditionRef,
+				kind: "Condition",
+			})
+		}
+		g.nodes = append(g.nodes, n)
+
+		edges := map[string]*graphEdge{}
+		addEdge := func(from, resource string) {
+			e, ok := edges[from]
+			if !ok {
+				e = &graphEdge{from: from, to: t.Name}
+				edges[from] = e
+				g.edges = append(g.edges, e)
+			}
+			if resource != "" {
+				e.resources = append(e.resources, resource)
+			}
+		}
+
+		for _, after := range t.RunAfter {
+			addEdge(after, "")
+		}
+		if t.Resources != nil {
+			for _, in := range t.Resources.Inputs {
+				for _, from := range in.From {
+					addEdge(from, in.Resource)
+				}
+			}
+		}
+	}
+
+	for _, e := range g.edges {
+		if g.node(e.from) == nil && g.missingNode(e.from) == nil {
+			g.missing = append(g.missing, &graphNode{id: e.from, name: e.from, missing: true})
+		}
+	}
+
+	return g
+}
+
+func (g *graph) missingNode(name string) *graphNode {
+	for _, n := range g.missing {
+		if n.name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// setStatus sets the status of the tasks and conditions from their runs in
+// the pipelinerun
+func (g *graph) setStatus(pr *v1alpha1.PipelineRun) {
+	for _, tr := range pr.Status.TaskRuns {
+		n := g.node(tr.PipelineTaskName)
+		if n == nil {
+			continue
+		}
+
+		if tr.Status != nil {
+			n.status = tr.Status.Conditions
+		}
+
+		for _, cc := range tr.ConditionChecks {
+			if cc.Status == nil {
+				continue
+			}
+			for _, c := range n.conditions {
+				if c.name == cc.ConditionName {
+					c.status = cc.Status.Conditions
+				}
+			}
+			if len(cc.Status.Conditions) != 0 && cc.Status.Conditions[0].Status == corev1.ConditionFalse {
+				n.skipped = true
+			}
+		}
+	}
+}
+
+func (g *graph) node(name string) *graphNode {
+	for _, n := range g.nodes {
+		if n.name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// targets returns the nodes an edge to the task goes in, the conditions of
+// the task when it has some
+func (g *graph) targets(to string) []*graphNode {
+	n := g.node(to)
+	if n == nil {
+		return nil
+	}
+	if len(n.conditions) != 0 {
+		return n.conditions
+	}
+	return []*graphNode{n}
+}
+
+// label describes the task, with its reference and retries
+func (n *graphNode) label() []string {
+	if n.missing {
+		return []string{n.name + missingMarker}
+	}
+	if n.kind == "Condition" {
+		return []string{n.name}
+	}
+
+	l := []string{n.name, n.kind + ": " + n.taskRef}
+	if n.retries > 0 {
+		l = append(l, fmt.Sprintf("retries: %d", n.retries))
+	}
+	return l
+}
+
+// state is the status of the run of the node: succeeded, failed, running,
+// skipped or empty when it did not run
+func (n *graphNode) state() string {
+	if len(n.status) == 0 {
+		if n.skipped {
+			return "skipped"
+		}
+		return ""
+	}
+
+	switch n.status[0].Status {
+	case corev1.ConditionTrue:
+		return "succeeded"
+	case corev1.ConditionFalse:
+		return "failed"
+	}
+	return "running"
+}
+
+func (n *graphNode) statusText() string {
+	if len(n.status) == 0 && n.skipped {
+		return "Skipped"
+	}
+	return formatted.Condition(n.status)
+}
+
+var dotColors = map[string]string{
+	"succeeded": "palegreen",
+	"failed":    "lightcoral",
+	"running":   "lightyellow",
+	"skipped":   "lightgray",
+}
+
+func (g *graph) dot(w io.Writer) {
+	fmt.Fprintf(w, "digraph %q {\n", g.name)
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+
+	for _, n := range g.nodes {
+		fmt.Fprintf(w, "  %q [label=%q%s];\n", n.id, strings.Join(n.label(), "\n"), dotFill(n))
+		for _, c := range n.conditions {
+			fmt.Fprintf(w, "  %q [label=%q, shape=diamond%s];\n", c.id, c.name, dotFill(c))
+			fmt.Fprintf(w, "  %q -> %q;\n", c.id, n.id)
+		}
+	}
+	for _, n := range g.missing {
+		fmt.Fprintf(w, "  %q [label=%q, style=dashed];\n", n.id, n.label()[0])
+	}
+
+	for _, e := range g.edges {
+		label := ""
+		if len(e.resources) != 0 {
+			label = fmt.Sprintf(" [label=%q]", strings.Join(e.resources, ", "))
+		}
+		for _, t := range g.targets(e.to) {
+			fmt.Fprintf(w, "  %q -> %q%s;\n", e.from, t.id, label)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func dotFill(n *graphNode) string {
+	if c, ok := dotColors[n.state()]; ok {
+		return fmt.Sprintf(", style=filled, fillcolor=%s", c)
+	}
+	return ""
+}
+
+var mermaidStyles = map[string]string{
+	"succeeded": "fill:#98fb98",
+	"failed":    "fill:#f08080",
+	"running":   "fill:#ffffe0",
+	"skipped":   "fill:#d3d3d3",
+	"missing":   "stroke-dasharray: 5 5",
+}
+
+func (g *graph) mermaid(w io.Writer) {
+	fmt.Fprintln(w, "graph LR")
+
+	states := map[string][]string{}
+	addState := func(n *graphNode) {
+		if s := n.state(); s != "" {
+			states[s] = append(states[s], mermaidID(n.id))
+		}
+	}
+
+	for _, n := range g.nodes {
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", mermaidID(n.id), strings.Join(n.label(), "<br/>"))
+		addState(n)
+		for _, c := range n.conditions {
+			fmt.Fprintf(w, "  %s{\"%s\"}\n", mermaidID(c.id), c.name)
+			fmt.Fprintf(w, "  %s --> %s\n", mermaidID(c.id), mermaidID(n.id))
+			addState(c)
+		}
+	}
+	for _, n := range g.missing {
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", mermaidID(n.id), n.label()[0])
+		states["missing"] = append(states["missing"], mermaidID(n.id))
+	}
+
+	for _, e := range g.edges {
+		arrow := "-->"
+		if len(e.resources) != 0 {
+			arrow = fmt.Sprintf("-->|%s|", strings.Join(e.resources, ", "))
+		}
+		for _, t := range g.targets(e.to) {
+			fmt.Fprintf(w, "  %s %s %s\n", mermaidID(e.from), arrow, mermaidID(t.id))
+		}
+	}
+
+	for _, s := range []string{"succeeded", "failed", "running", "skipped", "missing"} {
+		if len(states[s]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  classDef %s %s\n", s, mermaidStyles[s])
+		fmt.Fprintf(w, "  class %s %s\n", strings.Join(states[s], ","), s)
+	}
+}
+
+// mermaidID returns an id mermaid accepts for the node, the names of the
+// tasks cannot have underscores so they do not clash. The ids are prefixed
+// as tasks can be named after keywords of mermaid, like end.
+func mermaidID(id string) string {
+	return "task_" + strings.NewReplacer("-", "_", ".", "__").Replace(id)
+}
+
+var asciiColors = map[string]*color.Color{
+	"succeeded": color.New(color.FgGreen),
+	"failed":    color.New(color.FgRed),
+	"running":   color.New(color.FgYellow),
+	"skipped":   color.New(color.FgHiBlack),
+}
+
+// ascii prints the tasks by stage, a stage being the tasks which can run once
+// the tasks of the previous stages are done
+func (g *graph) ascii(w io.Writer) {
+	if len(g.nodes) == 0 {
+		fmt.Fprintf(w, "Pipeline %s has no tasks\n", g.name)
+		return
+	}
+
+	stages := g.stages()
+	last := 0
+	for _, s := range stages {
+		if s > last {
+			last = s
+		}
+	}
+
+	for stage := 1; stage <= last; stage++ {
+		if stage > 1 {
+			fmt.Fprintln(w, "    |\n    v")
+		}
+		fmt.Fprintf(w, "Stage %d\n", stage)
+
+		for _, n := range g.nodes {
+			if stages[n.name] != stage {
+				continue
+			}
+
+			l := n.label()
+			fmt.Fprintf(w, "  %s (%s)%s\n", l[0], strings.Join(l[1:], ", "), asciiStatus(n))
+			for _, c := range n.conditions {
+				fmt.Fprintf(w, "    when: %s%s\n", c.name, asciiStatus(c))
+			}
+			for _, e := range g.edges {
+				if e.to != n.name {
+					continue
+				}
+				from := e.from
+				if g.node(from) == nil {
+					from += missingMarker
+				}
+				if len(e.resources) == 0 {
+					fmt.Fprintf(w, "    after: %s\n", from)
+				} else {
+					fmt.Fprintf(w, "    after: %s (%s)\n", from, strings.Join(e.resources, ", "))
+				}
+			}
+		}
+	}
+}
+
+func asciiStatus(n *graphNode) string {
+	s := n.state()
+	if s == "" {
+		return ""
+	}
+	return " " + asciiColors[s].Sprint("["+n.statusText()+"]")
+}
+
+// stages returns the stage of each task, one more than the last stage of the
+// tasks it runs after
+func (g *graph) stages() map[string]int {
+	stages := map[string]int{}
+	visiting := map[string]bool{}
+
+	var visit func(name string) int
+	visit = func(name string) int {
+		if s, ok := stages[name]; ok {
+			return s
+		}
+		// pipelines are validated to be acyclic, this only guards against
+		// looping forever on an invalid one
+		if visiting[name] || g.node(name) == nil {
+			return 0
+		}
+		visiting[name] = true
+
+		stage := 1
+		for _, e := range g.edges {
+			if e.to != name {
+				continue
+			}
+			if s := visit(e.from) + 1; s > stage {
+				stage = s
+			}
+		}
+		stages[name] = stage
+		return stage
+	}
+
+	for _, n := range g.nodes {
+		visit(n.name)
+	}
+	return stages
+}
@@ -0,0 +1,335 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func duckStatus(status corev1.ConditionStatus, reason string) duckv1beta1.Status {
+	return duckv1beta1.Status{
+		Conditions: duckv1beta1.Conditions{
+			{Type: apis.ConditionSucceeded, Status: status, Reason: reason},
+		},
+	}
+}
+
+func TestPipelineGraph(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("release", "ns",
+			tb.PipelineSpec(
+				tb.PipelineDeclaredResource("app-image", "image"),
+				tb.PipelineTask("build", "build"),
+				tb.PipelineTask("lint", "golangci", func(pt *v1alpha1.PipelineTask) {
+					pt.TaskRef.Kind = v1alpha1.ClusterTaskKind
+				}),
+				tb.PipelineTask("test", "test",
+					tb.RunAfter("build"),
+					tb.PipelineTaskCondition("has-tests"),
+					tb.Retries(2),
+				),
+				tb.PipelineTask("deploy", "deploy",
+					tb.RunAfter("lint"),
+					tb.PipelineTaskInputResource("image", "app-image", tb.From("build", "test")),
+				),
+			),
+		),
+		tb.Pipeline("broken", "ns",
+			tb.PipelineSpec(
+				tb.PipelineTask("end", "notify",
+					tb.RunAfter("init"),
+				),
+			),
+		),
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("release-1", "ns",
+			tb.PipelineRunSpec("release"),
+			tb.PipelineRunStatus(
+				tb.PipelineRunTaskRunsStatus("release-1-build", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "build",
+					Status: &v1alpha1.TaskRunStatus{
+						Status: duckStatus(corev1.ConditionTrue, "Succeeded"),
+					},
+				}),
+				tb.PipelineRunTaskRunsStatus("release-1-lint", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "lint",
+					Status: &v1alpha1.TaskRunStatus{
+						Status: duckStatus(corev1.ConditionFalse, "Failed"),
+					},
+				}),
+				tb.PipelineRunTaskRunsStatus("release-1-test", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "test",
+					ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+						"release-1-test-has-tests": {
+							ConditionName: "has-tests",
+							Status: &v1alpha1.ConditionCheckStatus{
+								Status: duckStatus(corev1.ConditionFalse, "Failed"),
+							},
+						},
+					},
+				}),
+			),
+		),
+		tb.PipelineRun("missing-1", "ns",
+			tb.PipelineRunSpec("missing"),
+		),
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: ps, PipelineRuns: prs, Namespaces: ns})
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Ascii",
+			command: []string{"graph", "release", "-n", "ns"},
+			want: `Stage 1
+  build (Task: build)
+  lint (ClusterTask: golangci)
+    |
+    v
+Stage 2
+  test (Task: test, retries: 2)
+    when: has-tests
+    after: build
+    |
+    v
+Stage 3
+  deploy (Task: deploy)
+    after: lint
+    after: build (app-image)
+    after: test (app-image)
+`,
+		},
+		{
+			name:    "Dot",
+			command: []string{"graph", "release", "-n", "ns", "-o", "dot"},
+			want: `digraph "release" {
+  rankdir=LR;
+  node [shape=box];
+  "build" [label="build\nTask: build"];
+  "lint" [label="lint\nClusterTask: golangci"];
+  "test" [label="test\nTask: test\nretries: 2"];
+  "test.0" [label="has-tests", shape=diamond];
+  "test.0" -> "test";
+  "deploy" [label="deploy\nTask: deploy"];
+  "build" -> "test.0";
+  "lint" -> "deploy";
+  "build" -> "deploy" [label="app-image"];
+  "test" -> "deploy" [label="app-image"];
+}
+`,
+		},
+		{
+			name:    "Mermaid",
+			command: []string{"graph", "release", "-n", "ns", "-o", "mermaid"},
+			want: `graph LR
+  task_build["build<br/>Task: build"]
+  task_lint["lint<br/>ClusterTask: golangci"]
+  task_test["test<br/>Task: test<br/>retries: 2"]
+  task_test__0{"has-tests"}
+  task_test__0 --> task_test
+  task_deploy["deploy<br/>Task: deploy"]
+  task_build --> task_test__0
+  task_lint --> task_deploy
+  task_build -->|app-image| task_deploy
+  task_test -->|app-image| task_deploy
+`,
+		},
+		{
+			name:    "Pipelinerun",
+			command: []string{"graph", "--pipelinerun", "release-1", "-n", "ns"},
+			want: `Stage 1
+  build (Task: build) [Succeeded]
+  lint (ClusterTask: golangci) [Failed]
+    |
+    v
+Stage 2
+  test (Task: test, retries: 2) [Skipped]
+    when: has-tests [Failed]
+    after: build
+    |
+    v
+Stage 3
+  deploy (Task: deploy)
+    after: lint
+    after: build (app-image)
+    after: test (app-image)
+`,
+		},
+		{
+			name:    "Pipelinerun as dot",
+			command: []string{"graph", "--pipelinerun", "release-1", "-n", "ns", "-o", "dot"},
+			want: `digraph "release" {
+  rankdir=LR;
+  node [shape=box];
+  "build" [label="build\nTask: build", style=filled, fillcolor=palegreen];
+  "lint" [label="lint\nClusterTask: golangci", style=filled, fillcolor=lightcoral];
+  "test" [label="test\nTask: test\nretries: 2", style=filled, fillcolor=lightgray];
+  "test.0" [label="has-tests", shape=diamond, style=filled, fillcolor=lightcoral];
+  "test.0" -> "test";
+  "deploy" [label="deploy\nTask: deploy"];
+  "build" -> "test.0";
+  "lint" -> "deploy";
+  "build" -> "deploy" [label="app-image"];
+  "test" -> "deploy" [label="app-image"];
+}
+`,
+		},
+		{
+			name:    "Pipelinerun as mermaid",
+			command: []string{"graph", "--pipelinerun", "release-1", "-n", "ns", "-o", "mermaid"},
+			want: `graph LR
+  task_build["build<br/>Task: build"]
+  task_lint["lint<br/>ClusterTask: golangci"]
+  task_test["test<br/>Task: test<br/>retries: 2"]
+  task_test__0{"has-tests"}
+  task_test__0 --> task_test
+  task_deploy["deploy<br/>Task: deploy"]
+  task_build --> task_test__0
+  task_lint --> task_deploy
+  task_build -->|app-image| task_deploy
+  task_test -->|app-image| task_deploy
+  classDef succeeded fill:#98fb98
+  class task_build succeeded
+  classDef failed fill:#f08080
+  class task_lint,task_test__0 failed
+  classDef skipped fill:#d3d3d3
+  class task_test skipped
+`,
+		},
+		{
+			name:    "Missing task",
+			command: []string{"graph", "broken", "-n", "ns"},
+			want: `Stage 1
+  end (Task: notify)
+    after: init (missing)
+`,
+		},
+		{
+			name:    "Missing task as dot",
+			command: []string{"graph", "broken", "-n", "ns", "-o", "dot"},
+			want: `digraph "broken" {
+  rankdir=LR;
+  node [shape=box];
+  "end" [label="end\nTask: notify"];
+  "init" [label="init (missing)", style=dashed];
+  "init" -> "end";
+}
+`,
+		},
+		{
+			name:    "Missing task as mermaid",
+			command: []string{"graph", "broken", "-n", "ns", "-o", "mermaid"},
+			want: `graph LR
+  task_end["end<br/>Task: notify"]
+  task_init["init (missing)"]
+  task_init --> task_end
+  classDef missing stroke-dasharray: 5 5
+  class task_init missing
+`,
+		},
+		{
+			name:    "From file",
+			command: []string{"graph", "-f", "./testdata/pipeline.yaml", "-o", "dot"},
+			want: `digraph "test-pipeline" {
+  rankdir=LR;
+  node [shape=box];
+  "build-skaffold-web" [label="build-skaffold-web\nTask: build-docker-image-from-git-source"];
+  "deploy-web" [label="deploy-web\nTask: deploy-using-kubectl"];
+  "build-skaffold-web" -> "deploy-web" [label="web-image"];
+}
+`,
+		},
+		{
+			name:      "Unsupported format",
+			command:   []string{"graph", "release", "-n", "ns", "-o", "svg"},
+			wantError: true,
+			want:      `output format "svg" is not supported, use ascii, dot or mermaid`,
+		},
+		{
+			name:      "Name and file",
+			command:   []string{"graph", "release", "-f", "./testdata/pipeline.yaml"},
+			wantError: true,
+			want:      "--from cannot be used with the name of a pipeline",
+		},
+		{
+			name:      "No pipeline",
+			command:   []string{"graph", "-n", "ns"},
+			wantError: true,
+			want:      "pipeline name required, or use --from or --pipelinerun",
+		},
+		{
+			name:      "Not found pipeline",
+			command:   []string{"graph", "nonexistent", "-n", "ns"},
+			wantError: true,
+			want:      "pipeline name nonexistent does not exist in namespace ns",
+		},
+		{
+			name:      "Not found pipeline of pipelinerun",
+			command:   []string{"graph", "--pipelinerun", "missing-1", "-n", "ns"},
+			wantError: true,
+			want:      "pipeline name missing does not exist in namespace ns",
+		},
+		{
+			name:      "Not found pipelinerun",
+			command:   []string{"graph", "--pipelinerun", "nonexistent", "-n", "ns"},
+			wantError: true,
+			want:      `failed to find pipelinerun "nonexistent"`,
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+			pipeline := Command(p)
+
+			out, err := test.ExecuteCommand(pipeline, tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
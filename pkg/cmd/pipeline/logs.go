@@ -15,6 +15,7 @@
 package pipeline
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -63,6 +64,10 @@ Show logs of given pipeline for last run:
 Show logs for given pipeline and pipelinerun:
 
     tkn pipeline logs pipeline run -n namespace
+
+Search the logs of the last 20 runs of given pipeline which failed for lines matching a regular expression:
+
+    tkn pipeline logs pipeline -n namespace --grep "connection (refused|reset)" --runs 20 --status failed
 `
 	c := &cobra.Command{
 		Use:                   "logs",
@@ -89,19 +94,36 @@ Show logs for given pipeline and pipelinerun:
 				return err
 			}
 
-			return run(opts, args)
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return run(ctx, opts, args)
 		},
 	}
 	c.Flags().BoolVarP(&opts.Last, "last", "L", false, "show logs for last run")
 	c.Flags().BoolVarP(&opts.AllSteps, "all", "a", false, "show all logs including init steps injected by tekton")
 	c.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "stream live logs")
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "lists number of pipelineruns")
+	c.Flags().BoolVarP(&opts.Timestamps, "timestamps", "", false, "show the timestamp of each log line")
+	c.Flags().DurationVarP(&opts.Since, "since", "", 0, "only show logs newer than a relative duration like 30s, 10m or 2h")
+	c.Flags().StringVarP(&opts.SinceTime, "since-time", "", "", "only show logs after a date in RFC3339 format")
+	c.Flags().Int64VarP(&opts.Tail, "tail", "", 0, "number of most recent lines to show for each step (default: all)")
+	c.Flags().StringVarP(&opts.Output, "output", "o", "", "output format of the logs, json writes one JSON object per line (default: text)")
+	c.Flags().BoolVarP(&opts.Interleave, "interleave", "", false, "merge the timestamped lines of the tasks in chronological order instead of showing the logs task by task")
+	c.Flags().StringVarP(&opts.Grep, "grep", "", "", "search the logs of the last runs of the pipeline for lines matching this regular expression")
+	c.Flags().IntVarP(&opts.Runs, "runs", "", 10, "number of runs searched with --grep")
+	c.Flags().StringVarP(&opts.Status, "status", "", "", "only search the runs with this status with --grep: succeeded, failed, running or cancelled")
+	c.Flags().IntVarP(&opts.Parallel, "parallel", "", 4, "number of runs searched at the same time with --grep")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
 	return c
 }
 
-func run(opts *options.LogOptions, args []string) error {
+func run(ctx context.Context, opts *options.LogOptions, args []string) error {
+	if opts.Grep != "" {
+		return search(ctx, opts, args)
+	}
+
 	if err := initOpts(opts, args); err != nil {
 		return err
 	}
@@ -110,7 +132,16 @@ func run(opts *options.LogOptions, args []string) error {
 		return nil
 	}
 
-	return pipelinerun.Run(opts)
+	return pipelinerun.Run(ctx, opts)
+}
+
+func search(ctx context.Context, opts *options.LogOptions, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("--grep requires the name of the pipeline whose runs are searched")
+	}
+	opts.PipelineName = args[0]
+
+	return pipelinerun.SearchLogs(ctx, opts)
 }
 
 func initOpts(opts *options.LogOptions, args []string) error {
@@ -15,6 +15,8 @@
 package pipeline
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -25,6 +27,7 @@ import (
 	"github.com/jonboulle/clockwork"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/pods/fake"
 	"github.com/tektoncd/cli/pkg/test"
 	cb "github.com/tektoncd/cli/pkg/test/builder"
 	"github.com/tektoncd/cli/test/prompt"
@@ -563,8 +566,184 @@ func TestPipelineLog_Interactive(t *testing.T) {
 				opts.AskOpts = prompt.WithStdio(stdio)
 				opts.Stream = &cli.Stream{Out: stdio.Out, Err: stdio.Err}
 
-				return run(opts, tp.prompt.CmdArgs)
+				return run(context.Background(), opts, tp.prompt.CmdArgs)
 			})
 		})
 	}
 }
+
+func TestPipelineLog_grep(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	var (
+		prs   []*v1alpha1.PipelineRun
+		trs   []*v1alpha1.TaskRun
+		pods  []*corev1.Pod
+		tasks []fake.Log
+	)
+
+	runs := []struct {
+		name    string
+		started time.Duration
+		status  corev1.ConditionStatus
+		logs    []string
+	}{
+		{"output-pipeline-run-1", 40 * time.Minute, corev1.ConditionTrue, []string{"cloning", "done"}},
+		{"output-pipeline-run-2", 30 * time.Minute, corev1.ConditionTrue, []string{"error: connection refused", "retrying", "done"}},
+		{"output-pipeline-run-3", 20 * time.Minute, corev1.ConditionTrue, []string{"error: connection reset", "retrying", "error: connection refused"}},
+		{"output-pipeline-run-4", 10 * time.Minute, corev1.ConditionFalse, []string{"error: connection refused"}},
+		{"output-pipeline-run-5", 5 * time.Minute, corev1.ConditionUnknown, []string{"error: connection reset"}},
+	}
+
+	for _, r := range runs {
+		trName, pod := r.name+"-build", r.name+"-build-pod"
+		tr := tb.TaskRun(trName, ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("build"),
+			),
+			tb.TaskRunStatus(
+				tb.PodName(pod),
+				tb.TaskRunStartTime(clock.Now().Add(-r.started)),
+				tb.StatusCondition(apis.Condition{
+					Type:    apis.ConditionSucceeded,
+					Status:  r.status,
+					Message: "build exited with code 1",
+				}),
+				tb.StepState(
+					cb.StepName("build"),
+					tb.StateTerminated(0),
+				),
+			),
+		)
+		trs = append(trs, tr)
+
+		prs = append(prs, tb.PipelineRun(r.name, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", pipelineName),
+			tb.PipelineRunSpec(pipelineName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: r.status,
+				}),
+				tb.PipelineRunStartTime(clock.Now().Add(-r.started)),
+				tb.PipelineRunTaskRunsStatus(trName, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "build",
+					Status:           &tr.Status,
+				}),
+			),
+		))
+
+		pods = append(pods, tb.Pod(pod, ns,
+			tb.PodSpec(
+				tb.PodContainer("build", "build:latest"),
+			),
+		))
+
+		tasks = append(tasks, fake.Task(pod, fake.Step("build", r.logs...)))
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Pipelines: []*v1alpha1.Pipeline{
+			tb.Pipeline(pipelineName, ns,
+				tb.PipelineSpec(
+					tb.PipelineTask("build", "build"),
+				),
+			),
+		},
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		Pods:         pods,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ns,
+				},
+			},
+		},
+	})
+
+	testParams := []struct {
+		name   string
+		runs   int
+		status string
+		want   string
+	}{
+		{
+			name: "search all runs",
+			runs: 10,
+			want: "output-pipeline-run-5 (Running, started 5 minutes ago)\n" +
+				"[build : build] error: connection reset\n" +
+				"\n" +
+				"output-pipeline-run-4 (Failed, started 10 minutes ago)\n" +
+				"task build has failed: build exited with code 1\n" +
+				"\n" +
+				"output-pipeline-run-3 (Succeeded, started 20 minutes ago)\n" +
+				"[build : build] error: connection reset\n" +
+				"[build : build] error: connection refused\n" +
+				"\n" +
+				"output-pipeline-run-2 (Succeeded, started 30 minutes ago)\n" +
+				"[build : build] error: connection refused\n" +
+				"\n" +
+				"RUN                     STATUS      STARTED          MATCHES   \n" +
+				"output-pipeline-run-5   Running     5 minutes ago    1         \n" +
+				"output-pipeline-run-4   Failed      10 minutes ago   0         \n" +
+				"output-pipeline-run-3   Succeeded   20 minutes ago   2         \n" +
+				"output-pipeline-run-2   Succeeded   30 minutes ago   1         \n" +
+				"output-pipeline-run-1   Succeeded   40 minutes ago   0         \n" +
+				"\n" +
+				"First match in output-pipeline-run-2, started 30 minutes ago\n",
+		},
+		{
+			name:   "search the last succeeded run",
+			runs:   1,
+			status: "succeeded",
+			want: "output-pipeline-run-3 (Succeeded, started 20 minutes ago)\n" +
+				"[build : build] error: connection reset\n" +
+				"[build : build] error: connection refused\n" +
+				"\n" +
+				"RUN                     STATUS      STARTED          MATCHES   \n" +
+				"output-pipeline-run-3   Succeeded   20 minutes ago   2         \n" +
+				"\n" +
+				"First match in output-pipeline-run-3, started 20 minutes ago\n",
+		},
+		{
+			name:   "search the running run",
+			runs:   10,
+			status: "running",
+			want: "output-pipeline-run-5 (Running, started 5 minutes ago)\n" +
+				"[build : build] error: connection reset\n" +
+				"\n" +
+				"RUN                     STATUS    STARTED         MATCHES   \n" +
+				"output-pipeline-run-5   Running   5 minutes ago   1         \n" +
+				"\n" +
+				"First match in output-pipeline-run-5, started 5 minutes ago\n",
+		},
+		{
+			name:   "search without runs",
+			runs:   10,
+			status: "cancelled",
+			want:   "No pipelineruns found for pipeline: output-pipeline\n",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+			p.SetNamespace(ns)
+
+			out := new(bytes.Buffer)
+			opts := options.NewLogOptions(p)
+			opts.Stream = &cli.Stream{Out: out, Err: out}
+			opts.Streamer = fake.Streamer(fake.Logs(tasks...))
+			opts.Grep = "connection (refused|reset)"
+			opts.Runs = tp.runs
+			opts.Status = tp.status
+			opts.Parallel = 2
+
+			if err := run(context.Background(), opts, []string{pipelineName}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out.String())
+		})
+	}
+}
@@ -16,6 +16,7 @@ package pipeline
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -38,7 +39,7 @@ func RunPromptTest(t *testing.T, test promptTest, opts *options.LogOptions) {
 	test.runTest(t, test.procedure, func(stdio terminal.Stdio) error {
 		var err error
 		opts.AskOpts = WithStdio(stdio)
-		err = run(opts, test.cmdArgs)
+		err = run(context.Background(), opts, test.cmdArgs)
 		if err != nil {
 			return err
 		}
@@ -56,7 +57,7 @@ func (opts *startOptions) RunPromptTest(t *testing.T, test promptTest) {
 			Out: stdio.Out,
 			Err: stdio.Err,
 		}
-		err = opts.run(test.cmdArgs[0])
+		err = opts.run(context.Background(), test.cmdArgs[0])
 		if err != nil {
 			return err
 		}
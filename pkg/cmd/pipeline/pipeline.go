@@ -41,6 +41,8 @@ func Command(p cli.Params) *cobra.Command {
 		startCommand(p),
 		deleteCommand(p),
 		createCommand(p),
+		scheduleCommand(p),
+		graphCommand(p),
 	)
 	return cmd
 }
@@ -0,0 +1,273 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/flags"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/params"
+	"github.com/tektoncd/cli/pkg/helper/schedule"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+)
+
+const emptySchedulesMsg = "No schedules found"
+
+type scheduleOptions struct {
+	startOptions
+	Cron   string
+	Name   string
+	Image  string
+	DryRun bool
+}
+
+func scheduleCommand(p cli.Params) *cobra.Command {
+	opt := scheduleOptions{
+		startOptions: startOptions{cliparams: p},
+	}
+
+	c := &cobra.Command{
+		Use:   "schedule pipeline --cron SCHEDULE",
+		Short: "Start pipelines periodically",
+		Long: `Create a CronJob which periodically starts the pipeline, along with the
+ServiceAccount, Role and RoleBinding allowing it to create PipelineRuns.
+
+The PipelineRun is built from the given resources, params, service accounts
+and labels like with 'tkn pipeline start'.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: `Start Pipeline foo every night at 2am in namespace 'bar':
+
+    tkn pipeline schedule foo --cron "0 2 * * *" -r source=foo-git -p image=foo:nightly -n bar
+
+Print the objects of the schedule instead of creating them:
+
+    tkn pipeline schedule foo --cron "@daily" -r source=foo-git --dry-run
+`,
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := flags.InitParams(p, cmd); err != nil {
+				return err
+			}
+
+			return NameArg(args, p)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opt.stream = &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			return opt.schedule(args[0])
+		},
+	}
+
+	c.Flags().StringVar(&opt.Cron, "cron", "", "cron schedule at which the pipeline is started, e.g. \"0 2 * * *\"")
+	_ = c.MarkFlagRequired("cron")
+	c.Flags().StringVar(&opt.Name, "name", "", "name of the schedule (default: <pipeline>-schedule)")
+	c.Flags().StringVar(&opt.Image, "image", schedule.DefaultImage, "image providing kubectl which creates the pipelinerun")
+	c.Flags().BoolVar(&opt.DryRun, "dry-run", false, "print the objects of the schedule instead of creating them")
+	c.Flags().StringSliceVarP(&opt.Resources, "resource", "r", []string{}, "pass the resource name and ref as name=ref")
+	c.Flags().StringArrayVarP(&opt.Params, "param", "p", []string{}, "pass the param as key=value or key=value1,value2")
+	c.Flags().StringVarP(&opt.ServiceAccountName, "serviceaccount", "s", "", "pass the serviceaccount name")
+	flags.AddShellCompletion(c.Flags().Lookup("serviceaccount"), "__kubectl_get_serviceaccount")
+	c.Flags().StringSliceVar(&opt.ServiceAccounts, "task-serviceaccount", []string{}, "pass the service account corresponding to the task")
+	flags.AddShellCompletion(c.Flags().Lookup("task-serviceaccount"), "__kubectl_get_serviceaccount")
+	c.Flags().BoolVarP(&opt.Last, "last", "L", false, "schedule the pipeline using last pipelinerun values")
+	c.Flags().StringSliceVarP(&opt.Labels, "labels", "l", []string{}, "pass labels as label=value.")
+
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
+
+	c.AddCommand(
+		scheduleListCommand(p),
+		scheduleDeleteCommand(p),
+	)
+
+	return c
+}
+
+func (opt *scheduleOptions) schedule(pName string) error {
+	if err := schedule.ValidateCron(opt.Cron); err != nil {
+		return err
+	}
+
+	cs, err := opt.cliparams.Clients()
+	if err != nil {
+		return err
+	}
+
+	pipeline, err := getPipeline(cs.Tekton, opt.cliparams.Namespace(), pName)
+	if err != nil {
+		return err
+	}
+	params.FilterParamsByType(pipeline.Spec.Params)
+
+	pr, err := opt.buildPipelineRun(pName)
+	if err != nil {
+		return err
+	}
+
+	name := opt.Name
+	if name == "" {
+		name = pName + "-schedule"
+	}
+
+	s := &schedule.Schedule{
+		Name:      name,
+		Namespace: opt.cliparams.Namespace(),
+		Pipeline:  pName,
+		Cron:      opt.Cron,
+		Image:     opt.Image,
+		Run:       pr,
+	}
+
+	if opt.DryRun {
+		objs, err := s.Objects()
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(opt.stream.Out, "---\n%s", data)
+		}
+		return nil
+	}
+
+	if err := s.Create(cs.Kube); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opt.stream.Out, "Schedule created: %s\n", name)
+	return nil
+}
+
+func scheduleListCommand(p cli.Params) *cobra.Command {
+	c := &cobra.Command{
+		Use:     "list [pipeline]",
+		Aliases: []string{"ls"},
+		Short:   "Lists pipeline schedules in a namespace",
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: `List all pipeline schedules in namespace 'bar':
+
+    tkn pipeline schedule list -n bar
+
+List the schedules of Pipeline 'foo':
+
+    tkn pipeline schedule list foo -n bar
+`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			var pipeline string
+			if len(args) > 0 {
+				pipeline = args[0]
+			}
+
+			cs, err := p.Clients()
+			if err != nil {
+				return err
+			}
+
+			cjs, err := schedule.List(cs.Kube, p.Namespace(), pipeline)
+			if err != nil {
+				return fmt.Errorf("failed to list schedules from %s namespace: %s", p.Namespace(), err)
+			}
+
+			if len(cjs) == 0 {
+				fmt.Fprintln(cmd.OutOrStderr(), emptySchedulesMsg)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 5, 3, ' ', tabwriter.TabIndent)
+			fmt.Fprintln(w, "NAME\tPIPELINE\tSCHEDULE\tLAST SCHEDULED\t")
+			for i := range cjs {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+					cjs[i].Name,
+					schedule.Pipeline(&cjs[i]),
+					cjs[i].Spec.Schedule,
+					formatted.Age(cjs[i].Status.LastScheduleTime, p.Time()),
+				)
+			}
+			return w.Flush()
+		},
+	}
+
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
+	return c
+}
+
+func scheduleDeleteCommand(p cli.Params) *cobra.Command {
+	opts := &options.DeleteOptions{Resource: "schedule", ForceDelete: false}
+
+	c := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"rm"},
+		Short:   "Delete a pipeline schedule in a namespace",
+		Example: `Delete the schedule 'foo-schedule' in namespace 'bar':
+
+    tkn pipeline schedule delete foo-schedule -n bar
+`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				In:  cmd.InOrStdin(),
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			if err := opts.CheckOptions(s, args[0]); err != nil {
+				return err
+			}
+
+			cs, err := p.Clients()
+			if err != nil {
+				return errors.New("failed to create kubernetes client")
+			}
+
+			if err := schedule.Delete(cs.Kube, p.Namespace(), args[0]); err != nil {
+				return fmt.Errorf("failed to delete schedule %q: %s", args[0], err)
+			}
+			fmt.Fprintf(s.Out, "Schedule deleted: %s\n", args[0])
+			return nil
+		},
+	}
+	c.Flags().BoolVarP(&opts.ForceDelete, "force", "f", false, "Whether to force deletion (default: false)")
+
+	return c
+}
@@ -0,0 +1,214 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func schedulePipelines() []*v1alpha1.Pipeline {
+	return []*v1alpha1.Pipeline{
+		tb.Pipeline("test-pipeline", "ns",
+			tb.PipelineSpec(
+				tb.PipelineDeclaredResource("git-repo", "git"),
+				tb.PipelineParamSpec("pipeline-param", v1alpha1.ParamTypeString, tb.ParamSpecDefault("somethingdifferent")),
+				tb.PipelineTask("unit-test-1", "unit-test-task",
+					tb.PipelineTaskInputResource("workspace", "git-repo"),
+				),
+			),
+		),
+	}
+}
+
+func scheduleNamespaces() []*corev1.Namespace {
+	return []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+}
+
+func TestPipelineSchedule(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: schedulePipelines(), Namespaces: scheduleNamespaces()})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	got, err := test.ExecuteCommand(Command(p), "schedule", "test-pipeline",
+		"--cron", "0 2 * * *",
+		"-r=git-repo=scaffold-git",
+		"-p=pipeline-param=nightly",
+		"-s=builder",
+		"-l=jemange=desfrites",
+		"-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "Schedule created: test-pipeline-schedule\n", got)
+
+	cj, err := cs.Kube.BatchV1beta1().CronJobs("ns").Get("test-pipeline-schedule", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "0 2 * * *", cj.Spec.Schedule)
+	test.AssertOutput(t, "test-pipeline-schedule", cj.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName)
+
+	for _, get := range []func() error{
+		func() error {
+			_, err := cs.Kube.CoreV1().ServiceAccounts("ns").Get("test-pipeline-schedule", metav1.GetOptions{})
+			return err
+		},
+		func() error {
+			_, err := cs.Kube.RbacV1().Roles("ns").Get("test-pipeline-schedule", metav1.GetOptions{})
+			return err
+		},
+		func() error {
+			_, err := cs.Kube.RbacV1().RoleBindings("ns").Get("test-pipeline-schedule", metav1.GetOptions{})
+			return err
+		},
+	} {
+		if err := get(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	cm, err := cs.Kube.CoreV1().ConfigMaps("ns").Get("test-pipeline-schedule", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pr := v1alpha1.PipelineRun{}
+	if err := yaml.Unmarshal([]byte(cm.Data["pipelinerun.yaml"]), &pr); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "test-pipeline-run-", pr.GenerateName)
+	test.AssertOutput(t, "test-pipeline", pr.Spec.PipelineRef.Name)
+	test.AssertOutput(t, "builder", pr.Spec.ServiceAccountName)
+	test.AssertOutput(t, "desfrites", pr.Labels["jemange"])
+	test.AssertOutput(t, []v1alpha1.PipelineResourceBinding{
+		{Name: "git-repo", ResourceRef: &v1alpha1.PipelineResourceRef{Name: "scaffold-git"}},
+	}, pr.Spec.Resources)
+	test.AssertOutput(t, []v1alpha1.Param{
+		{Name: "pipeline-param", Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "nightly"}},
+	}, pr.Spec.Params)
+	for _, k := range []string{invoker.KubeUserAnnotation, invoker.OSUserAnnotation, invoker.HostAnnotation} {
+		if _, ok := pr.Annotations[k]; ok {
+			t.Errorf("Expected the pipelinerun template not to have the %s annotation", k)
+		}
+	}
+
+	got, err = test.ExecuteCommand(Command(p), "schedule", "list", "-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "NAME                     PIPELINE        SCHEDULE    LAST SCHEDULED   \n" +
+		"test-pipeline-schedule   test-pipeline   0 2 * * *   ---              \n"
+	test.AssertOutput(t, expected, got)
+
+	got, err = test.ExecuteCommand(Command(p), "schedule", "delete", "test-pipeline-schedule", "-f", "-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "Schedule deleted: test-pipeline-schedule\n", got)
+
+	got, err = test.ExecuteCommand(Command(p), "schedule", "list", "-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, emptySchedulesMsg+"\n", got)
+
+	if _, err := cs.Kube.CoreV1().ConfigMaps("ns").Get("test-pipeline-schedule", metav1.GetOptions{}); err == nil {
+		t.Error("Expected the configmap of the schedule to be deleted")
+	}
+}
+
+func TestPipelineSchedule_dry_run(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: schedulePipelines(), Namespaces: scheduleNamespaces()})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	got, err := test.ExecuteCommand(Command(p), "schedule", "test-pipeline",
+		"--cron", "@daily",
+		"--name", "nightly",
+		"-r=git-repo=scaffold-git",
+		"--dry-run",
+		"-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, kind := range []string{"ConfigMap", "ServiceAccount", "Role", "RoleBinding", "CronJob"} {
+		if !strings.Contains(got, "kind: "+kind+"\n") {
+			t.Errorf("Expected a %s in the output:\n%s", kind, got)
+		}
+	}
+
+	cjs, err := cs.Kube.BatchV1beta1().CronJobs("ns").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 0, len(cjs.Items))
+}
+
+func TestPipelineSchedule_errors(t *testing.T) {
+	testParams := []struct {
+		name    string
+		command []string
+		want    string
+	}{
+		{
+			name:    "invalid cron",
+			command: []string{"schedule", "test-pipeline", "--cron", "0 2 *", "-n", "ns"},
+			want:    "invalid cron schedule \"0 2 *\", expected five fields: minute hour day-of-month month day-of-week",
+		},
+		{
+			name:    "missing cron",
+			command: []string{"schedule", "test-pipeline", "-n", "ns"},
+			want:    "required flag(s) \"cron\" not set",
+		},
+		{
+			name:    "unknown pipeline",
+			command: []string{"schedule", "foo", "--cron", "@daily", "-n", "ns"},
+			want:    "pipeline name foo does not exist in namespace ns",
+		},
+		{
+			name:    "delete unknown schedule",
+			command: []string{"schedule", "delete", "foo", "-f", "-n", "ns"},
+			want:    "failed to delete schedule \"foo\": cronjobs.batch \"foo\" not found",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: schedulePipelines(), Namespaces: scheduleNamespaces()})
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+			_, err := test.ExecuteCommand(Command(p), tp.command...)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			test.AssertOutput(t, tp.want, err.Error())
+		})
+	}
+}
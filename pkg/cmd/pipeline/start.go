@@ -15,6 +15,7 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -27,10 +28,13 @@ import (
 	"github.com/tektoncd/cli/pkg/cmd/pipelineresource"
 	"github.com/tektoncd/cli/pkg/cmd/pipelinerun"
 	"github.com/tektoncd/cli/pkg/flags"
+	"github.com/tektoncd/cli/pkg/helper/git"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	"github.com/tektoncd/cli/pkg/helper/labels"
 	"github.com/tektoncd/cli/pkg/helper/options"
 	"github.com/tektoncd/cli/pkg/helper/params"
 	"github.com/tektoncd/cli/pkg/helper/pipeline"
+	"github.com/tektoncd/cli/pkg/helper/source"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
@@ -46,19 +50,32 @@ var (
 const (
 	invalidResource = "invalid input format for resource parameter: "
 	invalidSvc      = "invalid service account parameter: "
+	// gitFromLocalDetect is the value of --git-from-local when no resource
+	// name is given, the git resource is then looked up in the pipeline
+	gitFromLocalDetect = "*"
 )
 
-type startOptions struct {
-	cliparams          cli.Params
-	stream             *cli.Stream
-	askOpts            survey.AskOpt
+// StartOptions are the values a PipelineRun of a pipeline is built from,
+// given like the flags of 'tkn pipeline start'
+type StartOptions struct {
 	Params             []string
 	Resources          []string
 	ServiceAccountName string
 	ServiceAccounts    []string
 	Last               bool
 	Labels             []string
-	ShowLog            bool
+}
+
+type startOptions struct {
+	StartOptions
+	cliparams    cli.Params
+	stream       *cli.Stream
+	askOpts      survey.AskOpt
+	ShowLog      bool
+	GitFromLocal string
+	SourceDir    string
+	localGit     *v1alpha1.PipelineResourceBinding
+	sourceRes    string
 }
 
 type resourceOptionsFilter struct {
@@ -120,6 +137,20 @@ func startCommand(p cli.Params) *cobra.Command {
 
 For params value, if you want to provide multiple values, provide them comma separated
 like cat,foo,bar
+
+Bind the git resource of Pipeline foo to the remote url and HEAD commit of the
+git repository in the current directory:
+
+    tkn pipeline start foo --git-from-local
+
+or, when the Pipeline declares more than one git resource:
+
+    tkn pipeline start foo --git-from-local=source
+
+Upload the current directory, without the files ignored by git, and bind it
+as the git resource of Pipeline foo which is not bound with --resource:
+
+    tkn pipeline start foo --source-dir ./ -r image=my-image
 `,
 		SilenceUsage: true,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -135,7 +166,10 @@ like cat,foo,bar
 				Err: cmd.OutOrStderr(),
 			}
 
-			return opt.run(args[0])
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return opt.run(ctx, args[0])
 		},
 	}
 
@@ -148,18 +182,25 @@ like cat,foo,bar
 	flags.AddShellCompletion(c.Flags().Lookup("task-serviceaccount"), "__kubectl_get_serviceaccount")
 	c.Flags().BoolVarP(&opt.Last, "last", "L", false, "re-run the pipeline using last pipelinerun values")
 	c.Flags().StringSliceVarP(&opt.Labels, "labels", "l", []string{}, "pass labels as label=value.")
+	c.Flags().StringVar(&opt.GitFromLocal, "git-from-local", "", "bind the git resource to the remote url and HEAD commit of the git repository in the current directory, optionally naming the resource")
+	c.Flags().Lookup("git-from-local").NoOptDefVal = gitFromLocalDetect
+	c.Flags().StringVar(&opt.SourceDir, "source-dir", "", "upload the given local directory to the cluster and bind it as the git resource which is not bound with --resource")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipeline")
 
 	return c
 }
 
-func (opt *startOptions) run(pName string) error {
+func (opt *startOptions) run(ctx context.Context, pName string) error {
+	if opt.GitFromLocal != "" && opt.SourceDir != "" {
+		return errors.New("cannot use --git-from-local and --source-dir together")
+	}
+
 	if err := opt.getInput(pName); err != nil {
 		return err
 	}
 
-	return opt.startPipeline(pName)
+	return opt.startPipeline(ctx, pName)
 }
 
 func (opt *startOptions) getInput(pname string) error {
@@ -174,6 +215,18 @@ func (opt *startOptions) getInput(pname string) error {
 		return err
 	}
 
+	if opt.GitFromLocal != "" {
+		if err := opt.bindLocalGit(pipeline); err != nil {
+			return err
+		}
+	}
+
+	if opt.SourceDir != "" {
+		if err := opt.bindSourceDir(pipeline); err != nil {
+			return err
+		}
+	}
+
 	if len(opt.Resources) == 0 && !opt.Last {
 		pres, err := getPipelineResources(cs.Tekton, opt.cliparams.Namespace())
 		if err != nil {
@@ -200,6 +253,10 @@ func (opt *startOptions) getInput(pname string) error {
 
 func (opt *startOptions) getInputResources(resources resourceOptionsFilter, pipeline *v1alpha1.Pipeline) error {
 	for _, res := range pipeline.Spec.Resources {
+		if opt.boundLocally(res.Name) {
+			continue
+		}
+
 		options := getOptionsByType(resources, string(res.Type))
 		// directly create resource
 		if len(options) == 0 {
@@ -247,6 +304,76 @@ func (opt *startOptions) getInputResources(resources resourceOptionsFilter, pipe
 	return nil
 }
 
+// bindLocalGit binds the git resource of the pipeline to the remote and
+// HEAD revision of the git repository in the current directory
+func (opt *startOptions) bindLocalGit(pipeline *v1alpha1.Pipeline) error {
+	declared := map[string]v1alpha1.PipelineResourceType{}
+	for _, res := range pipeline.Spec.Resources {
+		declared[res.Name] = res.Type
+	}
+
+	name := opt.GitFromLocal
+	if name == gitFromLocalDetect {
+		name = ""
+	}
+
+	name, err := git.FindResourceName(name, declared)
+	if err != nil {
+		return fmt.Errorf("pipeline %s: %s", pipeline.Name, err)
+	}
+
+	local, err := git.FromLocal(".")
+	if err != nil {
+		return err
+	}
+
+	for _, w := range local.Warnings {
+		fmt.Fprintf(opt.stream.Err, "Warning: %s\n", w)
+	}
+
+	opt.localGit = &v1alpha1.PipelineResourceBinding{
+		Name:         name,
+		ResourceSpec: local.Spec(),
+	}
+	return nil
+}
+
+// bindSourceDir finds the git resource of the pipeline which is not bound
+// with --resource, to be bound to the uploaded source directory
+func (opt *startOptions) bindSourceDir(pipeline *v1alpha1.Pipeline) error {
+	bound, err := parseRes(opt.Resources)
+	if err != nil {
+		return err
+	}
+
+	declared := map[string]v1alpha1.PipelineResourceType{}
+	for _, res := range pipeline.Spec.Resources {
+		if _, ok := bound[res.Name]; !ok {
+			declared[res.Name] = res.Type
+		}
+	}
+
+	name, err := git.FindResourceName("", declared)
+	if err == git.ErrManyResources {
+		return fmt.Errorf("pipeline %s: more than one git resource is not bound, bind the others with --resource", pipeline.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("pipeline %s: %s", pipeline.Name, err)
+	}
+
+	opt.sourceRes = name
+	return nil
+}
+
+// boundLocally reports whether the resource is bound by --git-from-local
+// or --source-dir
+func (opt *startOptions) boundLocally(name string) bool {
+	if opt.localGit != nil && opt.localGit.Name == name {
+		return true
+	}
+	return opt.sourceRes == name
+}
+
 func (opt *startOptions) getInputParams(pipeline *v1alpha1.Pipeline) error {
 	for _, param := range pipeline.Spec.Params {
 		var ans, ques, defaultValue string
@@ -374,26 +501,30 @@ func getOptionsByType(resources resourceOptionsFilter, restype string) []string
 	return []string{}
 }
 
-func (opt *startOptions) startPipeline(pName string) error {
+// BuildPipelineRun returns the PipelineRun of pipeline pName in the
+// namespace of p, with the resources, params, service accounts and labels of
+// opts merged into spec, or into the spec of the last run of the pipeline
+// with opts.Last. It is not annotated with who started it, as it is also the
+// template of the runs of schedules.
+func BuildPipelineRun(p cli.Params, pName string, spec v1alpha1.PipelineRunSpec, opts StartOptions) (*v1alpha1.PipelineRun, error) {
 	pr := &v1alpha1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:    opt.cliparams.Namespace(),
+			Namespace:    p.Namespace(),
 			GenerateName: pName + "-run-",
 		},
-		Spec: v1alpha1.PipelineRunSpec{
-			PipelineRef: &v1alpha1.PipelineRef{Name: pName},
-		},
+		Spec: spec,
 	}
+	pr.Spec.PipelineRef = &v1alpha1.PipelineRef{Name: pName}
 
-	cs, err := opt.cliparams.Clients()
+	cs, err := p.Clients()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if opt.Last {
-		prLast, err := pipeline.LastRun(cs.Tekton, pName, opt.cliparams.Namespace())
+	if opts.Last {
+		prLast, err := pipeline.LastRun(cs.Tekton, pName, p.Namespace())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		pr.Spec.Resources = prLast.Spec.Resources
 		pr.Spec.Params = prLast.Spec.Params
@@ -402,35 +533,92 @@ func (opt *startOptions) startPipeline(pName string) error {
 		pr.Spec.ServiceAccountNames = prLast.Spec.ServiceAccountNames
 	}
 
-	if err := mergeRes(pr, opt.Resources); err != nil {
-		return err
+	if err := mergeRes(pr, opts.Resources); err != nil {
+		return nil, err
 	}
 
-	labels, err := labels.MergeLabels(pr.ObjectMeta.Labels, opt.Labels)
+	labels, err := labels.MergeLabels(pr.ObjectMeta.Labels, opts.Labels)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	pr.ObjectMeta.Labels = labels
 
-	param, err := params.MergeParam(pr.Spec.Params, opt.Params)
+	param, err := params.MergeParam(pr.Spec.Params, opts.Params)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	pr.Spec.Params = param
 
-	if err := mergeSvc(pr, opt.ServiceAccounts); err != nil {
+	if err := mergeSvc(pr, opts.ServiceAccounts); err != nil {
+		return nil, err
+	}
+
+	if len(opts.ServiceAccountName) > 0 {
+		pr.Spec.ServiceAccountName = opts.ServiceAccountName
+	}
+
+	return pr, nil
+}
+
+// buildPipelineRun returns the PipelineRun built from the options, with the
+// git resource bound by --git-from-local
+func (opt *startOptions) buildPipelineRun(pName string) (*v1alpha1.PipelineRun, error) {
+	pr, err := BuildPipelineRun(opt.cliparams, pName, v1alpha1.PipelineRunSpec{}, opt.StartOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.localGit != nil {
+		mergeBindings(pr, map[string]v1alpha1.PipelineResourceBinding{
+			opt.localGit.Name: *opt.localGit,
+		})
+	}
+
+	return pr, nil
+}
+
+func (opt *startOptions) startPipeline(ctx context.Context, pName string) error {
+	pr, err := opt.buildPipelineRun(pName)
+	if err != nil {
+		return err
+	}
+	invoker.Current(opt.cliparams).Annotate(&pr.ObjectMeta)
+
+	cs, err := opt.cliparams.Clients()
+	if err != nil {
 		return err
 	}
 
-	if len(opt.ServiceAccountName) > 0 {
-		pr.Spec.ServiceAccountName = opt.ServiceAccountName
+	var upload *source.Upload
+	if opt.sourceRes != "" {
+		if upload, err = source.UploadDir(opt.stream.Out, cs.Kube, opt.cliparams.Namespace(), opt.SourceDir); err != nil {
+			return err
+		}
+		mergeBindings(pr, map[string]v1alpha1.PipelineResourceBinding{
+			opt.sourceRes: {Name: opt.sourceRes, ResourceSpec: upload.Spec()},
+		})
 	}
 
 	prCreated, err := cs.Tekton.TektonV1alpha1().PipelineRuns(opt.cliparams.Namespace()).Create(pr)
 	if err != nil {
+		if upload != nil {
+			_ = upload.Delete()
+		}
 		return err
 	}
 
+	if upload != nil {
+		owner := metav1.OwnerReference{
+			APIVersion: "tekton.dev/v1alpha1",
+			Kind:       "PipelineRun",
+			Name:       prCreated.Name,
+			UID:        prCreated.UID,
+		}
+		if err := upload.SetOwner(owner); err != nil {
+			fmt.Fprintf(opt.stream.Err, "Warning: source %s will not be deleted along with the pipelinerun: %s\n", upload.Name, err)
+		}
+	}
+
 	fmt.Fprintf(opt.stream.Out, "Pipelinerun started: %s\n", prCreated.Name)
 	if !opt.ShowLog {
 		fmt.Fprintf(opt.stream.Out, "\nIn order to track the pipelinerun progress run:\ntkn pipelinerun logs %s -f -n %s\n", prCreated.Name, prCreated.Namespace)
@@ -446,7 +634,7 @@ func (opt *startOptions) startPipeline(pName string) error {
 		Params:          opt.cliparams,
 		AllSteps:        false,
 	}
-	return pipelinerun.Run(runLogOpts)
+	return pipelinerun.Run(ctx, runLogOpts)
 }
 
 func mergeRes(pr *v1alpha1.PipelineRun, optRes []string) error {
@@ -455,8 +643,13 @@ func mergeRes(pr *v1alpha1.PipelineRun, optRes []string) error {
 		return err
 	}
 
+	mergeBindings(pr, res)
+	return nil
+}
+
+func mergeBindings(pr *v1alpha1.PipelineRun, res map[string]v1alpha1.PipelineResourceBinding) {
 	if len(res) == 0 {
-		return nil
+		return
 	}
 
 	for i := range pr.Spec.Resources {
@@ -468,7 +661,6 @@ func mergeRes(pr *v1alpha1.PipelineRun, optRes []string) error {
 	for _, v := range res {
 		pr.Spec.Resources = append(pr.Spec.Resources, v)
 	}
-	return nil
 }
 
 func mergeSvc(pr *v1alpha1.PipelineRun, optSvc []string) error {
@@ -17,7 +17,11 @@ package pipeline
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +30,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/jonboulle/clockwork"
 	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	"github.com/tektoncd/cli/pkg/helper/pipeline"
 	"github.com/tektoncd/cli/pkg/test"
 	cb "github.com/tektoncd/cli/pkg/test/builder"
@@ -171,7 +176,7 @@ func Test_start_pipeline(t *testing.T) {
 	}
 
 	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: ps, Namespaces: ns})
-	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube, User: "jane"}
 	pipeline := Command(p)
 
 	got, _ := test.ExecuteCommand(pipeline, "start", pipelineName,
@@ -206,6 +211,203 @@ func Test_start_pipeline(t *testing.T) {
 		t.Errorf("Error labels generated is different Labels Got: %+v", pr.Items[0].ObjectMeta.Labels)
 	}
 
+	test.AssertOutput(t, "jane", pr.Items[0].ObjectMeta.Annotations[invoker.KubeUserAnnotation])
+	test.AssertOutput(t, "dev", pr.Items[0].ObjectMeta.Annotations[invoker.VersionAnnotation])
+}
+
+func Test_start_pipeline_git_from_local(t *testing.T) {
+	pipelineName := "test-pipeline"
+
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, "ns",
+			tb.PipelineSpec(
+				tb.PipelineDeclaredResource("git-repo", "git"),
+				tb.PipelineDeclaredResource("build-image", "image"),
+				tb.PipelineParamSpec("pipeline-param", v1alpha1.ParamTypeString, tb.ParamSpecDefault("somethingdifferent")),
+				tb.PipelineTask("unit-test-1", "unit-test-task",
+					tb.PipelineTaskInputResource("workspace", "git-repo"),
+				),
+			), // spec
+		), // pipeline
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "tkn-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.email=tkn@example.com", "-c", "user.name=tkn", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"remote", "add", "origin", "https://github.com/tektoncd/cli"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	rev, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: ps, Namespaces: ns})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	pipeline := Command(p)
+
+	_, err = test.ExecuteCommand(pipeline, "start", pipelineName,
+		"--git-from-local=build-image",
+		"-r=build-image=some-image",
+		"-p=pipeline-param=value1",
+		"-n", "ns")
+	test.AssertOutput(t, "pipeline test-pipeline: resource build-image is of type image, not git", err.Error())
+
+	pipeline = Command(p)
+	_, err = test.ExecuteCommand(pipeline, "start", pipelineName,
+		"--git-from-local",
+		"-r=build-image=some-image",
+		"-p=pipeline-param=value1",
+		"-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pr, err := cs.Pipeline.TektonV1alpha1().PipelineRuns("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Errorf("Error listing pipelineruns %s", err.Error())
+	}
+
+	test.AssertOutput(t, 2, len(pr.Items[0].Spec.Resources))
+	for _, v := range pr.Items[0].Spec.Resources {
+		if v.Name == "git-repo" {
+			test.AssertOutput(t, &v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.ResourceParam{
+					{Name: "url", Value: "https://github.com/tektoncd/cli"},
+					{Name: "revision", Value: strings.TrimSpace(string(rev))},
+				},
+			}, v.ResourceSpec)
+		}
+	}
+}
+
+func Test_start_pipeline_source_dir(t *testing.T) {
+	pipelineName := "test-pipeline"
+
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, "ns",
+			tb.PipelineSpec(
+				tb.PipelineDeclaredResource("git-repo", "git"),
+				tb.PipelineDeclaredResource("git-docs", "git"),
+				tb.PipelineTask("unit-test-1", "unit-test-task",
+					tb.PipelineTaskInputResource("workspace", "git-repo"),
+				),
+			), // spec
+		), // pipeline
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "tkn-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(dir+"/main.go", []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: ps, Namespaces: ns})
+	watcher := watch.NewFake()
+	cs.Kube.PrependWatchReactor("pods", k8stest.DefaultWatchReactor(watcher, nil))
+	cs.Kube.PrependReactor("create", "pods", func(action k8stest.Action) (bool, runtime.Object, error) {
+		created := action.(k8stest.CreateAction).GetObject().(*corev1.Pod)
+		created.Status.Phase = corev1.PodRunning
+		created.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}
+		pod := created.DeepCopy()
+		go func() {
+			time.Sleep(time.Second)
+			watcher.Modify(pod)
+		}()
+		return false, nil, nil
+	})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	pipeline := Command(p)
+	_, err = test.ExecuteCommand(pipeline, "start", pipelineName,
+		"--source-dir", dir,
+		"-n", "ns")
+	test.AssertOutput(t, "pipeline test-pipeline: more than one git resource is not bound, bind the others with --resource", err.Error())
+
+	pipeline = Command(p)
+	_, err = test.ExecuteCommand(pipeline, "start", pipelineName,
+		"--source-dir", dir,
+		"--git-from-local",
+		"-n", "ns")
+	test.AssertOutput(t, "cannot use --git-from-local and --source-dir together", err.Error())
+
+	pipeline = Command(p)
+	got, err := test.ExecuteCommand(pipeline, "start", pipelineName,
+		"--source-dir", dir,
+		"-r=git-docs=docs-git",
+		"-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cms, err := cs.Kube.CoreV1().ConfigMaps("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 1, len(cms.Items))
+	url := "git://" + cms.Items[0].Name + ".ns.svc:9418/source"
+
+	expected := "Source uploaded from " + dir + " to " + url + "\n" +
+		"Pipelinerun started: \n\nIn order to track the pipelinerun progress run:\ntkn pipelinerun logs  -f -n ns\n"
+	test.AssertOutput(t, expected, got)
+
+	pr, err := cs.Pipeline.TektonV1alpha1().PipelineRuns("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Errorf("Error listing pipelineruns %s", err.Error())
+	}
+
+	test.AssertOutput(t, 2, len(pr.Items[0].Spec.Resources))
+	for _, v := range pr.Items[0].Spec.Resources {
+		if v.Name == "git-repo" {
+			test.AssertOutput(t, &v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.ResourceParam{
+					{Name: "url", Value: url},
+					{Name: "revision", Value: "master"},
+				},
+			}, v.ResourceSpec)
+		}
+	}
+	test.AssertOutput(t, "PipelineRun", cms.Items[0].OwnerReferences[0].Kind)
 }
 
 func Test_start_pipeline_showlogs_false(t *testing.T) {
@@ -2552,10 +2754,12 @@ func startOpts(ns string, cs pipelinetest.Clients, last bool, svc string, svcs [
 	}
 	p.SetNamespace(ns)
 	startOp := startOptions{
-		cliparams:          &p,
-		Last:               last,
-		ServiceAccountName: svc,
-		ServiceAccounts:    svcs,
+		cliparams: &p,
+		StartOptions: StartOptions{
+			Last:               last,
+			ServiceAccountName: svc,
+			ServiceAccounts:    svcs,
+		},
 	}
 
 	return &startOp
@@ -21,10 +21,12 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	validateinput "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/cli/pkg/printer"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const templ = `Name:	{{ .PipelineResource.Name }}
@@ -53,7 +55,7 @@ FIELDNAME	SECRETNAME
 `
 
 func describeCommand(p cli.Params) *cobra.Command {
-	f := cliopts.NewPrintFlags("describe")
+	opts := options.NewDescribeOptions()
 	eg := `Describe a PipelineResource of name 'foo' in namespace 'bar':
 
     tkn resource describe foo -n bar
@@ -83,16 +85,16 @@ or
 				return err
 			}
 
-			return printPipelineResourceDescription(s, p, args[0])
+			return printPipelineResourceDescription(s, p, args[0], opts)
 		},
 	}
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipelineresource")
-	f.AddFlags(c)
+	opts.AddFlags(c)
 	return c
 }
 
-func printPipelineResourceDescription(s *cli.Stream, p cli.Params, preName string) error {
+func printPipelineResourceDescription(s *cli.Stream, p cli.Params, preName string, opts *options.DescribeOptions) error {
 	cs, err := p.Clients()
 	if err != nil {
 		return fmt.Errorf("failed to create tekton client")
@@ -103,6 +105,17 @@ func printPipelineResourceDescription(s *cli.Stream, p cli.Params, preName strin
 		return fmt.Errorf("failed to find pipelineresource %q", preName)
 	}
 
+	if opts.Output() != "" {
+		// NOTE: this is required for -o json|yaml to work properly since
+		// tektoncd go client fails to set these; probably a bug
+		pre.GetObjectKind().SetGroupVersionKind(
+			schema.GroupVersionKind{
+				Version: "tekton.dev/v1alpha1",
+				Kind:    "PipelineResource",
+			})
+		return printer.PrintObject(s.Out, pre, opts.PrintFlags)
+	}
+
 	var data = struct {
 		PipelineResource *v1alpha1.PipelineResource
 		Params           cli.Params
@@ -111,8 +124,12 @@ func printPipelineResourceDescription(s *cli.Stream, p cli.Params, preName strin
 		Params:           p,
 	}
 
+	t, err := opts.Template("Describe PipelineResource", templ, template.FuncMap{})
+	if err != nil {
+		return err
+	}
+
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("Describe PipelineResource").Parse(templ))
 
 	err = t.Execute(w, data)
 	if err != nil {
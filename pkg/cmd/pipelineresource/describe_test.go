@@ -149,3 +149,46 @@ func TestPipelineResourceDescribe_WithSecretParams(t *testing.T) {
 
 	test.AssertOutput(t, strings.Join(expected, "\n"), out)
 }
+
+func TestPipelineResourceDescribe_output(t *testing.T) {
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ns-1",
+			},
+		},
+	}
+
+	pres := []*v1alpha1.PipelineResource{
+		tb.PipelineResource("test-1", "test-ns-1",
+			tb.PipelineResourceSpec("image",
+				tb.PipelineResourceSpecParam("URL", "quay.io/tekton/controller"),
+			),
+		),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineResources: pres, Namespaces: ns})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	pipelineresource := Command(p)
+	out, err := test.ExecuteCommand(pipelineresource, "desc", "test-1", "-n", "test-ns-1", "-o", "yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{
+		"apiVersion: tekton.dev/v1alpha1",
+		"kind: PipelineResource",
+		"metadata:",
+		"  creationTimestamp: null",
+		"  name: test-1",
+		"  namespace: test-ns-1",
+		"spec:",
+		"  params:",
+		"  - name: URL",
+		"    value: quay.io/tekton/controller",
+		"  type: image",
+		"status: {}",
+		"",
+	}
+
+	test.AssertOutput(t, strings.Join(expected, "\n"), out)
+}
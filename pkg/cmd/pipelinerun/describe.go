@@ -17,17 +17,24 @@ package pipelinerun
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/events"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	phelper "github.com/tektoncd/cli/pkg/helper/pipeline"
+	prhelper "github.com/tektoncd/cli/pkg/helper/pipelinerun"
+	"github.com/tektoncd/cli/pkg/helper/results"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/cli/pkg/printer"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const templ = `Name:	{{ .PipelineRun.Name }}
@@ -86,10 +93,31 @@ NAME	TASK NAME	STARTED	DURATION	STATUS
 {{ $taskrun.TaskrunName }}	{{ $taskrun.PipelineTaskName }}	{{ formatAge $taskrun.Status.StartTime $.Params.Time }}	{{ formatDuration $taskrun.Status.StartTime $taskrun.Status.CompletionTime }}	{{ formatCondition $taskrun.Status.Conditions }}
 {{- end }}
 {{- end }}
+{{- $l := len .Results }}{{ if ne $l 0 }}
+
+Results
+TASK NAME	RESOURCE	KEY	VALUE
+{{- range $r := .Results }}
+{{ $r.Task }}	{{ $r.Resource }}	{{ $r.Key }}	{{ $r.Value }}
+{{- end }}
+{{- end }}
+{{- if .ShowEvents }}
+
+Events
+{{- $l := len .Events }}{{ if eq $l 0 }}
+No events
+{{- else }}
+LAST SEEN	TYPE	REASON	OBJECT	MESSAGE
+{{- range $e := .Events }}
+{{ $e.Seen $.Params.Time }}	{{ $e.Type }}	{{ $e.Reason }}	{{ $e.Object }}	{{ $e.Message }}
+{{- end }}
+{{- end }}
+{{- end }}
 `
 
 func describeCommand(p cli.Params) *cobra.Command {
-	f := cliopts.NewPrintFlags("describe")
+	opts := options.NewDescribeOptions()
+	showEvents := true
 	eg := `Describe a PipelineRun of name 'foo' in namespace 'bar':
 
     tkn pipelinerun describe foo -n bar
@@ -97,6 +125,10 @@ func describeCommand(p cli.Params) *cobra.Command {
 or
 
     tkn pr desc foo -n bar
+
+Describe the last PipelineRun of the Pipeline 'foo' in namespace 'bar':
+
+    tkn pr desc --last --pipeline foo -n bar
 `
 
 	c := &cobra.Command{
@@ -104,7 +136,7 @@ or
 		Aliases:      []string{"desc"},
 		Short:        "Describe a pipelinerun in a namespace",
 		Example:      eg,
-		Args:         cobra.MinimumNArgs(1),
+		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
 		Annotations: map[string]string{
 			"commandType": "main",
@@ -119,17 +151,74 @@ or
 				return err
 			}
 
-			return printPipelineRunDescription(s, args[0], p)
+			prName, err := pipelineRunName(p, opts, args)
+			if err != nil {
+				return err
+			}
+
+			return printPipelineRunDescription(s, prName, p, showEvents, opts)
 		},
 	}
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipelinerun")
-	f.AddFlags(c)
+	opts.AddFlags(c)
+	c.Flags().BoolVarP(&opts.Last, "last", "L", false, "describe the most recent pipelinerun")
+	c.Flags().StringVarP(&opts.PipelineName, "pipeline", "", "", "only describe or select the pipelineruns of this pipeline")
+	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "number of pipelineruns to select from when no name is given")
+	c.Flags().BoolVarP(&showEvents, "events", "", true, "show the events of the pipelinerun, its taskruns and their pods")
 
 	return c
 }
 
-func printPipelineRunDescription(s *cli.Stream, prName string, p cli.Params) error {
+// pipelineRunName returns the name of the pipelinerun to describe: the one
+// given, the last one with --last or the one selected in a prompt
+func pipelineRunName(p cli.Params, opts *options.DescribeOptions, args []string) (string, error) {
+	if len(args) != 0 {
+		if opts.Last {
+			return "", fmt.Errorf("--last cannot be used with the name of a pipelinerun")
+		}
+		return args[0], nil
+	}
+
+	if opts.Last {
+		cs, err := p.Clients()
+		if err != nil {
+			return "", fmt.Errorf("failed to create tekton client")
+		}
+
+		pr, err := phelper.LastRun(cs.Tekton, opts.PipelineName, p.Namespace())
+		if err != nil {
+			return "", err
+		}
+		return pr.Name, nil
+	}
+
+	if !opts.Interactive {
+		return "", fmt.Errorf("pipelinerun name required, or use --last to describe the most recent one")
+	}
+
+	lOpts := metav1.ListOptions{}
+	if opts.PipelineName != "" {
+		lOpts.LabelSelector = fmt.Sprintf("tekton.dev/pipeline=%s", opts.PipelineName)
+	}
+
+	prs, err := prhelper.GetAllPipelineRuns(p, lOpts, opts.Limit)
+	if err != nil {
+		return "", err
+	}
+
+	if len(prs) == 0 {
+		return "", fmt.Errorf("No pipelineruns found")
+	}
+
+	if len(prs) == 1 {
+		return strings.Fields(prs[0])[0], nil
+	}
+
+	return opts.Ask(options.ResourceNamePipelineRun, prs)
+}
+
+func printPipelineRunDescription(s *cli.Stream, prName string, p cli.Params, showEvents bool, opts *options.DescribeOptions) error {
 	cs, err := p.Clients()
 	if err != nil {
 		return fmt.Errorf("failed to create tekton client")
@@ -140,6 +229,17 @@ func printPipelineRunDescription(s *cli.Stream, prName string, p cli.Params) err
 		return fmt.Errorf("failed to find pipelinerun %q", prName)
 	}
 
+	if opts.Output() != "" {
+		// NOTE: this is required for -o json|yaml to work properly since
+		// tektoncd go client fails to set these; probably a bug
+		pr.GetObjectKind().SetGroupVersionKind(
+			schema.GroupVersionKind{
+				Version: "tekton.dev/v1alpha1",
+				Kind:    "PipelineRun",
+			})
+		return printer.PrintObject(s.Out, pr, opts.PrintFlags)
+	}
+
 	var trl taskrunList
 
 	if len(pr.Status.TaskRuns) != 0 {
@@ -147,14 +247,45 @@ func printPipelineRunDescription(s *cli.Stream, prName string, p cli.Params) err
 		sort.Sort(trl)
 	}
 
+	var evs []events.Event
+	if showEvents {
+		objects := []corev1.ObjectReference{{Kind: "PipelineRun", Name: pr.Name, UID: pr.UID}}
+		for name, trs := range pr.Status.TaskRuns {
+			objects = append(objects, corev1.ObjectReference{Kind: "TaskRun", Name: name})
+			if trs.Status != nil {
+				objects = append(objects, corev1.ObjectReference{Kind: "Pod", Name: trs.Status.PodName})
+				for _, rs := range trs.Status.RetriesStatus {
+					objects = append(objects, corev1.ObjectReference{Kind: "Pod", Name: rs.PodName})
+				}
+			}
+			for cname, cc := range trs.ConditionChecks {
+				objects = append(objects, corev1.ObjectReference{Kind: "TaskRun", Name: cname})
+				if cc.Status != nil {
+					objects = append(objects, corev1.ObjectReference{Kind: "Pod", Name: cc.Status.PodName})
+				}
+			}
+		}
+
+		if evs, err = events.List(cs.Kube, p.Namespace(), objects...); err != nil {
+			fmt.Fprintf(s.Err, "Failed to list the events of pipelinerun %s: %s\n", pr.Name, err)
+			showEvents = false
+		}
+	}
+
 	var data = struct {
 		PipelineRun *v1alpha1.PipelineRun
 		Params      cli.Params
 		TaskrunList taskrunList
+		Results     []results.Result
+		ShowEvents  bool
+		Events      []events.Event
 	}{
 		PipelineRun: pr,
 		Params:      p,
 		TaskrunList: trl,
+		Results:     results.PipelineRun(pr),
+		ShowEvents:  showEvents,
+		Events:      evs,
 	}
 
 	funcMap := template.FuncMap{
@@ -166,8 +297,12 @@ func printPipelineRunDescription(s *cli.Stream, prName string, p cli.Params) err
 		"pipelineResourceRefExists": validate.PipelineResourceRefExists,
 	}
 
+	t, err := opts.Template("Describe Pipelinerun", templ, funcMap)
+	if err != nil {
+		return err
+	}
+
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("Describe Pipelinerun").Funcs(funcMap).Parse(templ))
 
 	if err = t.Execute(w, data); err != nil {
 		fmt.Fprintf(s.Err, "Failed to execute template")
@@ -15,12 +15,20 @@
 package pipelinerun
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/AlecAivazis/survey/v2/terminal"
+	goexpect "github.com/Netflix/go-expect"
 	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	"github.com/tektoncd/cli/pkg/test"
 	cb "github.com/tektoncd/cli/pkg/test/builder"
+	"github.com/tektoncd/cli/test/prompt"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	pipelinetest "github.com/tektoncd/pipeline/test"
@@ -142,6 +150,9 @@ No params
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   Succeeded
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -231,6 +242,9 @@ Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-2   t-2         5 minutes ago   4 minutes   Succeeded
 tr-1   t-1         8 minutes ago   3 minutes   Succeeded
+
+Events
+No events
 `
 	test.AssertOutput(t, expected, actual)
 
@@ -314,6 +328,9 @@ No params
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   Failed
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -392,6 +409,9 @@ No params
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -462,6 +482,9 @@ No params
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -546,6 +569,9 @@ test-param   param-value
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   Succeeded
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -596,6 +622,9 @@ No params
 
 Taskruns
 No taskruns
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -644,6 +673,9 @@ No params
 
 Taskruns
 No taskruns
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -726,6 +758,9 @@ test-param   param-value
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   Succeeded
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -805,7 +840,429 @@ No params
 Taskruns
 NAME   TASK NAME   STARTED         DURATION    STATUS
 tr-1   t-1         8 minutes ago   3 minutes   Succeeded
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
 }
+
+func TestPipelineRunDescribe_events(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("tr-1", "ns",
+			tb.TaskRunStatus(
+				tb.PodName("tr-1-pod"),
+				tb.TaskRunStartTime(clock.Now().Add(2*time.Minute)),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+				}),
+			),
+		),
+	}
+	trs[0].Status.RetriesStatus = []v1alpha1.TaskRunStatus{
+		{PodName: "tr-1-pod-retry1"},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			tb.PipelineRun("pipeline-run", "ns",
+				cb.PipelineRunCreationTimestamp(clock.Now()),
+				tb.PipelineRunLabel("tekton.dev/pipeline", "pipeline"),
+				tb.PipelineRunSpec("pipeline"),
+				tb.PipelineRunStatus(
+					tb.PipelineRunTaskRunsStatus("tr-1", &v1alpha1.PipelineRunTaskRunStatus{
+						PipelineTaskName: "t-1",
+						Status:           &trs[0].Status,
+						ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+							"tr-1-check": {
+								ConditionName: "check",
+								Status: &v1alpha1.ConditionCheckStatus{
+									PodName: "tr-1-check-pod",
+								},
+							},
+						},
+					}),
+					tb.PipelineRunStatusCondition(apis.Condition{
+						Status: corev1.ConditionUnknown,
+						Reason: resources.ReasonRunning,
+					}),
+					tb.PipelineRunStartTime(clock.Now()),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	events := []*corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "PipelineRun", Name: "pipeline-run"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Started",
+			LastTimestamp:  metav1.NewTime(clock.Now()),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-1-pod"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedScheduling",
+			Message:        "0/3 nodes are available: 3 Insufficient cpu.",
+			LastTimestamp:  metav1.NewTime(clock.Now().Add(3 * time.Minute)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e4", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-1-pod-retry1"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "OOMKilled",
+			LastTimestamp:  metav1.NewTime(clock.Now().Add(time.Minute)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e5", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-1-check-pod"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Pulled",
+			LastTimestamp:  metav1.NewTime(clock.Now().Add(time.Minute)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e3", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "other-pod"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Scheduled",
+			LastTimestamp:  metav1.NewTime(clock.Now()),
+		},
+	}
+	for _, e := range events {
+		if _, err := cs.Kube.CoreV1().Events("ns").Create(e); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+	clock.Advance(10 * time.Minute)
+	actual, err := test.ExecuteCommand(Command(p), "desc", "pipeline-run", "-n", "ns")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:           pipeline-run
+Namespace:      ns
+Pipeline Ref:   pipeline
+
+Status
+STARTED          DURATION   STATUS
+10 minutes ago   ---        Running
+
+Resources
+No resources
+
+Params
+No params
+
+Taskruns
+NAME   TASK NAME   STARTED         DURATION   STATUS
+tr-1   t-1         8 minutes ago   ---        Running
+
+Events
+LAST SEEN        TYPE      REASON             OBJECT                     MESSAGE
+10 minutes ago   Normal    Started            PipelineRun/pipeline-run   
+9 minutes ago    Warning   OOMKilled          Pod/tr-1-pod-retry1        
+9 minutes ago    Normal    Pulled             Pod/tr-1-check-pod         
+7 minutes ago    Warning   FailedScheduling   Pod/tr-1-pod               0/3 nodes are available: 3 Insufficient cpu.
+`
+	test.AssertOutput(t, expected, actual)
+}
+
+func TestPipelineRunDescribe_output(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			tb.PipelineRun("pipeline-run", "ns",
+				cb.PipelineRunCreationTimestamp(clock.Now()),
+				tb.PipelineRunLabel("tekton.dev/pipeline", "pipeline"),
+				tb.PipelineRunSpec("pipeline"),
+				tb.PipelineRunStatus(
+					tb.PipelineRunTaskRunsStatus("tr-1", &v1alpha1.PipelineRunTaskRunStatus{
+						PipelineTaskName: "t-1",
+					}),
+					tb.PipelineRunStatusCondition(apis.Condition{
+						Status: corev1.ConditionTrue,
+						Reason: resources.ReasonSucceeded,
+					}),
+					tb.PipelineRunStartTime(clock.Now()),
+					cb.PipelineRunCompletionTime(clock.Now().Add(5*time.Minute)),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	dir, err := ioutil.TempDir("", "tkn-describe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpl := filepath.Join(dir, "describe.tmpl")
+	content := `{{ .PipelineRun.Name }}	{{ formatCondition .PipelineRun.Status.Conditions }}	{{ formatDuration .PipelineRun.Status.StartTime .PipelineRun.Status.CompletionTime }}
+{{- range $tr := .TaskrunList }}
+{{ $tr.TaskrunName }}	{{ $tr.PipelineTaskName }}
+{{- end }}
+`
+	if err := ioutil.WriteFile(tmpl, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testParams := []struct {
+		name    string
+		command []string
+		want    string
+	}{
+		{
+			name:    "JSON path",
+			command: []string{"desc", "pipeline-run", "-n", "ns", "-o", "jsonpath={.kind} {.spec.pipelineRef.name}"},
+			want:    "PipelineRun pipeline",
+		},
+		{
+			name:    "Template file",
+			command: []string{"desc", "pipeline-run", "-n", "ns", "--template-file", tmpl},
+			want: `pipeline-run   Succeeded   5 minutes
+tr-1           t-1
+`,
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+			out, err := test.ExecuteCommand(Command(p), tp.command...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
+
+func describeRuns(t *testing.T, clock clockwork.Clock) pipelinetest.Clients {
+	var prs []*v1alpha1.PipelineRun
+	for _, r := range []struct {
+		name     string
+		pipeline string
+		started  time.Duration
+	}{
+		{"pr-1", "pipeline", 10 * time.Minute},
+		{"pr-2", "pipeline", 5 * time.Minute},
+		{"pr-3", "other", time.Minute},
+	} {
+		prs = append(prs, tb.PipelineRun(r.name, "ns",
+			cb.PipelineRunCreationTimestamp(clock.Now().Add(-r.started)),
+			tb.PipelineRunLabel("tekton.dev/pipeline", r.pipeline),
+			tb.PipelineRunSpec(r.pipeline),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStartTime(clock.Now().Add(-r.started)),
+			),
+		))
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		PipelineRuns: prs,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+	return cs
+}
+
+func TestPipelineRunDescribe_last(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	cs := describeRuns(t, clock)
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Last pipelinerun",
+			command: []string{"desc", "--last", "-n", "ns", "-o", "jsonpath={.metadata.name}"},
+			want:    "pr-3",
+		},
+		{
+			name:    "Last pipelinerun of pipeline",
+			command: []string{"desc", "--last", "--pipeline", "pipeline", "-n", "ns", "-o", "jsonpath={.metadata.name}"},
+			want:    "pr-2",
+		},
+		{
+			name:      "No pipelinerun of pipeline",
+			command:   []string{"desc", "--last", "--pipeline", "nope", "-n", "ns"},
+			wantError: true,
+			want:      "no pipelineruns related to pipeline nope found in namespace ns",
+		},
+		{
+			name:      "Last and name",
+			command:   []string{"desc", "pr-1", "--last", "-n", "ns"},
+			wantError: true,
+			want:      "--last cannot be used with the name of a pipelinerun",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+			out, err := test.ExecuteCommand(Command(p), tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
+
+func Test_pipelineRunName(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	cs := describeRuns(t, clock)
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+	p.SetNamespace("ns")
+
+	opts := options.NewDescribeOptions()
+	opts.Interactive = false
+	if _, err := pipelineRunName(p, opts, nil); err == nil {
+		t.Fatal("error expected here")
+	} else {
+		test.AssertOutput(t, "pipelinerun name required, or use --last to describe the most recent one", err.Error())
+	}
+
+	opts.Interactive = true
+	opts.PipelineName = "other"
+	name, err := pipelineRunName(p, opts, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "pr-3", name)
+
+	opts.PipelineName = "pipeline"
+	pr := prompt.Prompt{
+		Procedure: func(c *goexpect.Console) error {
+			if _, err := c.ExpectString("Select pipelinerun:"); err != nil {
+				return err
+			}
+			if _, err := c.ExpectString("pr-1 started 10 minutes ago"); err != nil {
+				return err
+			}
+			if _, err := c.SendLine(string(terminal.KeyArrowDown)); err != nil {
+				return err
+			}
+			if _, err := c.ExpectEOF(); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	pr.RunTest(t, pr.Procedure, func(stdio terminal.Stdio) error {
+		opts.AskOpts = prompt.WithStdio(stdio)
+		name, err = pipelineRunName(p, opts, nil)
+		if err != nil {
+			return err
+		}
+		if name != "pr-1" {
+			return errors.New("unexpected pipelinerun " + name)
+		}
+		return nil
+	})
+}
+
+func TestPipelineRunDescribe_results(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			tb.PipelineRun("release", "ns",
+				tb.PipelineRunSpec("release"),
+				tb.PipelineRunStatus(
+					tb.PipelineRunTaskRunsStatus("release-build-a9f3", &v1alpha1.PipelineRunTaskRunStatus{
+						PipelineTaskName: "build",
+						Status: &v1alpha1.TaskRunStatus{
+							ResourcesResult: []v1alpha1.PipelineResourceResult{
+								{Key: "digest", Value: "sha256:1234", ResourceRef: v1alpha1.PipelineResourceRef{Name: "app-image"}},
+							},
+						},
+					}),
+					tb.PipelineRunStatusCondition(apis.Condition{
+						Status: corev1.ConditionTrue,
+						Reason: resources.ReasonSucceeded,
+					}),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+	actual, err := test.ExecuteCommand(Command(p), "desc", "release", "-n", "ns", "--events=false")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:           release
+Namespace:      ns
+Pipeline Ref:   release
+
+Status
+STARTED   DURATION   STATUS
+---       ---        Succeeded
+
+Resources
+No resources
+
+Params
+No params
+
+Taskruns
+NAME                 TASK NAME   STARTED   DURATION   STATUS
+release-build-a9f3   build       ---       ---        ---
+
+Results
+TASK NAME   RESOURCE    KEY      VALUE
+build       app-image   digest   sha256:1234
+`
+	test.AssertOutput(t, expected, actual)
+}
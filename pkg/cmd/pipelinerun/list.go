@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	prhsort "github.com/tektoncd/cli/pkg/helper/pipelinerun/sort"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
 	"github.com/tektoncd/cli/pkg/printer"
@@ -37,7 +38,8 @@ const (
 )
 
 type ListOptions struct {
-	Limit int
+	Limit     int
+	StartedBy string
 }
 
 func listCommand(p cli.Params) *cobra.Command {
@@ -51,6 +53,10 @@ func listCommand(p cli.Params) *cobra.Command {
 List all PipelineRuns in a namespace 'foo':
 
     tkn pr list -n foo
+
+List all PipelineRuns started by user 'jane' in namespace 'bar':
+
+    tkn pr list --started-by jane -n bar
 `
 
 	c := &cobra.Command{
@@ -77,7 +83,7 @@ List all PipelineRuns in a namespace 'foo':
 				return nil
 			}
 
-			prs, err := list(p, pipeline, opts.Limit)
+			prs, err := list(p, pipeline, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to list pipelineruns from %s namespace \n", p.Namespace())
 				return err
@@ -111,11 +117,12 @@ List all PipelineRuns in a namespace 'foo':
 
 	f.AddFlags(c)
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 0, "limit pipelineruns listed (default: return all pipelineruns)")
+	c.Flags().StringVar(&opts.StartedBy, "started-by", "", "show only the pipelineruns started by the given kubeconfig or local user")
 
 	return c
 }
 
-func list(p cli.Params, pipeline string, limit int) (*v1alpha1.PipelineRunList, error) {
+func list(p cli.Params, pipeline string, opts *ListOptions) (*v1alpha1.PipelineRunList, error) {
 	cs, err := p.Clients()
 	if err != nil {
 		return nil, err
@@ -134,6 +141,17 @@ func list(p cli.Params, pipeline string, limit int) (*v1alpha1.PipelineRunList,
 		return nil, err
 	}
 
+	if opts.StartedBy != "" {
+		filtered := prs.Items[:0]
+		for _, pr := range prs.Items {
+			if invoker.IsStartedBy(pr.ObjectMeta, opts.StartedBy) {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs.Items = filtered
+	}
+
+	limit := opts.Limit
 	prslen := len(prs.Items)
 
 	if prslen != 0 {
@@ -168,16 +186,24 @@ func printFormatted(s *cli.Stream, prs *v1alpha1.PipelineRunList, c clockwork.Cl
 	}
 
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	fmt.Fprintln(w, "NAME\tSTARTED\tDURATION\tSTATUS\t")
+	fmt.Fprintln(w, "NAME\tSTARTED\tDURATION\tSTATUS\tSTARTED BY\t")
 	for _, pr := range prs.Items {
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n",
 			pr.Name,
 			formatted.Age(pr.Status.StartTime, c),
 			formatted.Duration(pr.Status.StartTime, pr.Status.CompletionTime),
 			formatted.Condition(pr.Status.Conditions),
+			startedBy(pr.ObjectMeta),
 		)
 	}
 
 	return w.Flush()
 }
+
+func startedBy(meta v1.ObjectMeta) string {
+	if u := invoker.StartedBy(meta); u != "" {
+		return u
+	}
+	return "---"
+}
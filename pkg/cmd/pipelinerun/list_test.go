@@ -22,6 +22,7 @@ import (
 
 	"github.com/jonboulle/clockwork"
 	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	"github.com/tektoncd/cli/pkg/test"
 	cb "github.com/tektoncd/cli/pkg/test/builder"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -47,6 +48,7 @@ func TestListPipelineRuns(t *testing.T) {
 			tb.PipelineRunStatus(),
 		),
 		tb.PipelineRun("pr1-1", "namespace",
+			tb.PipelineRunAnnotation(invoker.KubeUserAnnotation, "jane"),
 			tb.PipelineRunLabel("tekton.dev/pipeline", "pipeline"),
 			tb.PipelineRunStatus(
 				tb.PipelineRunStatusCondition(apis.Condition{
@@ -112,8 +114,19 @@ func TestListPipelineRuns(t *testing.T) {
 			args:      []string{"list", "pipeline", "-n", "namespace"},
 			wantError: false,
 			expected: []string{
-				"NAME    STARTED          DURATION   STATUS      ",
-				"pr1-1   59 minutes ago   1 minute   Succeeded   ",
+				"NAME    STARTED          DURATION   STATUS      STARTED BY   ",
+				"pr1-1   59 minutes ago   1 minute   Succeeded   jane         ",
+				"",
+			},
+		},
+		{
+			name:      "by user who started",
+			command:   command(t, prs, clock.Now(), ns),
+			args:      []string{"list", "--started-by", "jane", "-n", "namespace"},
+			wantError: false,
+			expected: []string{
+				"NAME    STARTED          DURATION   STATUS      STARTED BY   ",
+				"pr1-1   59 minutes ago   1 minute   Succeeded   jane         ",
 				"",
 			},
 		},
@@ -123,12 +136,12 @@ func TestListPipelineRuns(t *testing.T) {
 			args:      []string{"list", "-n", "namespace"},
 			wantError: false,
 			expected: []string{
-				"NAME    STARTED          DURATION   STATUS               ",
-				"pr0-1   ---              ---        ---                  ",
-				"pr3-1   ---              ---        ---                  ",
-				"pr1-1   59 minutes ago   1 minute   Succeeded            ",
-				"pr2-2   2 hours ago      1 minute   Failed               ",
-				"pr2-1   3 hours ago      ---        Succeeded(Running)   ",
+				"NAME    STARTED          DURATION   STATUS               STARTED BY   ",
+				"pr0-1   ---              ---        ---                  ---          ",
+				"pr3-1   ---              ---        ---                  ---          ",
+				"pr1-1   59 minutes ago   1 minute   Succeeded            jane         ",
+				"pr2-2   2 hours ago      1 minute   Failed               ---          ",
+				"pr2-1   3 hours ago      ---        Succeeded(Running)   ---          ",
 				"",
 			},
 		},
@@ -152,8 +165,8 @@ func TestListPipelineRuns(t *testing.T) {
 			args:      []string{"list", "-n", "namespace", "--limit", fmt.Sprintf("%d", 1)},
 			wantError: false,
 			expected: []string{
-				"NAME    STARTED   DURATION   STATUS   ",
-				"pr0-1   ---       ---        ---      ",
+				"NAME    STARTED   DURATION   STATUS   STARTED BY   ",
+				"pr0-1   ---       ---        ---      ---          ",
 				"",
 			},
 		},
@@ -172,12 +185,12 @@ func TestListPipelineRuns(t *testing.T) {
 			args:      []string{"list", "-n", "namespace", "--limit", fmt.Sprintf("%d", 7)},
 			wantError: false,
 			expected: []string{
-				"NAME    STARTED          DURATION   STATUS               ",
-				"pr0-1   ---              ---        ---                  ",
-				"pr3-1   ---              ---        ---                  ",
-				"pr1-1   59 minutes ago   1 minute   Succeeded            ",
-				"pr2-2   2 hours ago      1 minute   Failed               ",
-				"pr2-1   3 hours ago      ---        Succeeded(Running)   ",
+				"NAME    STARTED          DURATION   STATUS               STARTED BY   ",
+				"pr0-1   ---              ---        ---                  ---          ",
+				"pr3-1   ---              ---        ---                  ---          ",
+				"pr1-1   59 minutes ago   1 minute   Succeeded            jane         ",
+				"pr2-2   2 hours ago      1 minute   Failed               ---          ",
+				"pr2-1   3 hours ago      ---        Succeeded(Running)   ---          ",
 				"",
 			},
 		},
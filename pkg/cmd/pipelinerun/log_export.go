@@ -0,0 +1,462 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/cmd/taskrun"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	trh "github.com/tektoncd/cli/pkg/helper/taskrun"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const manifestFile = "manifest.json"
+
+// Manifest describes the run whose logs are exported
+type Manifest struct {
+	PipelineRun *v1alpha1.PipelineRun `json:"pipelinerun"`
+	Tasks       []TaskManifest        `json:"tasks"`
+	Files       []string              `json:"files"`
+	Errors      []string              `json:"errors,omitempty"`
+}
+
+// TaskManifest is the status of a TaskRun, or of a condition check, of the
+// PipelineRun
+type TaskManifest struct {
+	Task           string         `json:"task"`
+	TaskRun        string         `json:"taskrun"`
+	Condition      string         `json:"condition,omitempty"`
+	Status         string         `json:"status"`
+	StartTime      *metav1.Time   `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time   `json:"completionTime,omitempty"`
+	Duration       string         `json:"duration"`
+	Steps          []StepManifest `json:"steps"`
+}
+
+// StepManifest is the state of a step and the file holding its logs
+type StepManifest struct {
+	Step       string       `json:"step"`
+	Attempt    int          `json:"attempt,omitempty"`
+	File       string       `json:"file,omitempty"`
+	ExitCode   *int32       `json:"exitCode,omitempty"`
+	Reason     string       `json:"reason,omitempty"`
+	StartedAt  *metav1.Time `json:"startedAt,omitempty"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// LogExporter writes the logs of each step to its own file,
+// <task>/<step>.log, or <task>/attempt-<n>/<step>.log when the attempts are
+// numbered, under Dir
+type LogExporter struct {
+	Dir string
+
+	// files are the open files of the steps whose logs are being read
+	files map[string]*os.File
+	// paths are the files of the steps, by TaskRun, attempt and step
+	paths map[string]string
+	// written are all the files created under Dir
+	written map[string]bool
+	// numbered is set when the logs are those of numbered attempts
+	numbered bool
+	errors   []string
+}
+
+// NewLogExporter returns a LogExporter writing the logs under dir
+func NewLogExporter(dir string) *LogExporter {
+	return &LogExporter{
+		Dir:     dir,
+		files:   map[string]*os.File{},
+		paths:   map[string]string{},
+		written: map[string]bool{},
+	}
+}
+
+// Write saves the logs and reports the errors on the error stream, the
+// channels are drained even when a file cannot be written so that the
+// reader isn't blocked
+func (e *LogExporter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) error {
+	var werr error
+
+	for logC != nil || errC != nil {
+		select {
+		case l, ok := <-logC:
+			if !ok {
+				logC = nil
+				continue
+			}
+			if werr != nil {
+				continue
+			}
+			werr = e.write(l)
+
+		case err, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			msg := strings.TrimSpace(err.Error())
+			if msg == "" {
+				continue
+			}
+			e.errors = append(e.errors, msg)
+			fmt.Fprintf(s.Err, "%s\n", msg)
+		}
+	}
+
+	for _, f := range e.files {
+		if err := f.Close(); err != nil && werr == nil {
+			werr = err
+		}
+	}
+
+	return werr
+}
+
+func (e *LogExporter) write(l Log) error {
+	path := filepath.Join(l.Task, l.Step+".log")
+	if l.Attempt > 0 {
+		e.numbered = true
+		path = filepath.Join(l.Task, fmt.Sprintf("attempt-%d", l.Attempt), l.Step+".log")
+	}
+
+	switch l.Type {
+	case taskrun.StepStart:
+		return nil
+	case taskrun.StepEnd:
+		return e.close(path)
+	}
+
+	f, ok := e.files[path]
+	if !ok {
+		var err error
+		if f, err = e.open(path); err != nil {
+			return err
+		}
+		e.files[path] = f
+		e.paths[stepKey(l.TaskRun, l.Attempt, l.Step)] = path
+	}
+
+	msg := l.Log
+	if l.Type == taskrun.ConditionResult {
+		msg = "condition check " + l.Log
+	}
+	if !l.Timestamp.IsZero() {
+		msg = formatted.Timestamp(l.Timestamp) + " " + msg
+	}
+
+	_, err := fmt.Fprintln(f, msg)
+	return err
+}
+
+// open creates the file of a step, or reopens it when lines of the step come
+// after its end
+func (e *LogExporter) open(path string) (*os.File, error) {
+	full := filepath.Join(e.Dir, path)
+	if e.written[path] {
+		return os.OpenFile(full, os.O_WRONLY|os.O_APPEND, 0644)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(full)
+	if err != nil {
+		return nil, err
+	}
+	e.written[path] = true
+	return f, nil
+}
+
+// close closes the file of a step once its logs have been read, so that
+// only the files of the steps being read are open
+func (e *LogExporter) close(path string) error {
+	f, ok := e.files[path]
+	if !ok {
+		return nil
+	}
+	delete(e.files, path)
+	return f.Close()
+}
+
+func stepKey(taskRun string, attempt int, step string) string {
+	return fmt.Sprintf("%s/%d/%s", taskRun, attempt, step)
+}
+
+// Manifest returns the manifest of the exported logs of the run
+func (e *LogExporter) Manifest(pr *v1alpha1.PipelineRun, runs []trh.Run) *Manifest {
+	// the client does not set the kind of the objects it gets
+	run := pr.DeepCopy()
+	run.GetObjectKind().SetGroupVersionKind(
+		schema.GroupVersionKind{
+			Version: "tekton.dev/v1alpha1",
+			Kind:    "PipelineRun",
+		})
+
+	m := &Manifest{
+		PipelineRun: run,
+		Tasks:       []TaskManifest{},
+		Files:       []string{},
+		Errors:      e.errors,
+	}
+
+	for path := range e.written {
+		m.Files = append(m.Files, path)
+	}
+	sort.Strings(m.Files)
+
+	for _, r := range runs {
+		m.Tasks = append(m.Tasks, e.taskManifest(pr, r))
+	}
+
+	return m
+}
+
+func (e *LogExporter) taskManifest(pr *v1alpha1.PipelineRun, r trh.Run) TaskManifest {
+	tm := TaskManifest{
+		Task:      r.Task,
+		TaskRun:   r.Name,
+		Condition: r.Condition,
+		Steps:     []StepManifest{},
+	}
+
+	if r.Condition != "" {
+		cc := conditionCheckStatus(pr, r.Name)
+		if cc == nil {
+			tm.Status = formatted.Condition(nil)
+			tm.Duration = formatted.Duration(nil, nil)
+			return tm
+		}
+
+		tm.Status = formatted.Condition(cc.Conditions)
+		tm.StartTime, tm.CompletionTime = cc.StartTime, cc.CompletionTime
+		tm.Duration = formatted.Duration(cc.StartTime, cc.CompletionTime)
+
+		step := "condition/" + r.Condition
+		tm.Steps = append(tm.Steps, e.stepManifest(r.Name, 0, step, v1alpha1.StepState{ContainerState: cc.Check}))
+		return tm
+	}
+
+	trs, ok := pr.Status.TaskRuns[r.Name]
+	if !ok || trs.Status == nil {
+		tm.Status = formatted.Condition(nil)
+		tm.Duration = formatted.Duration(nil, nil)
+		return tm
+	}
+
+	tm.Status = formatted.Condition(trs.Status.Conditions)
+	tm.StartTime, tm.CompletionTime = trs.Status.StartTime, trs.Status.CompletionTime
+	tm.Duration = formatted.Duration(trs.Status.StartTime, trs.Status.CompletionTime)
+
+	// the steps of all the attempts are listed when their logs are
+	// numbered by attempt
+	attempt := 0
+	if e.numbered {
+		for i, rs := range trs.Status.RetriesStatus {
+			for _, s := range rs.Steps {
+				tm.Steps = append(tm.Steps, e.stepManifest(r.Name, i+1, s.Name, s))
+			}
+		}
+		attempt = len(trs.Status.RetriesStatus) + 1
+	}
+
+	for _, s := range trs.Status.Steps {
+		tm.Steps = append(tm.Steps, e.stepManifest(r.Name, attempt, s.Name, s))
+	}
+
+	return tm
+}
+
+func (e *LogExporter) stepManifest(taskRun string, attempt int, step string, state v1alpha1.StepState) StepManifest {
+	sm := StepManifest{
+		Step:    step,
+		Attempt: attempt,
+		File:    e.paths[stepKey(taskRun, attempt, step)],
+	}
+
+	if state.Running != nil {
+		sm.StartedAt = &state.Running.StartedAt
+	}
+
+	if t := state.Terminated; t != nil {
+		exitCode := t.ExitCode
+		sm.ExitCode = &exitCode
+		sm.Reason = t.Reason
+		sm.StartedAt = &t.StartedAt
+		sm.FinishedAt = &t.FinishedAt
+	}
+
+	return sm
+}
+
+func conditionCheckStatus(pr *v1alpha1.PipelineRun, name string) *v1alpha1.ConditionCheckStatus {
+	for _, trs := range pr.Status.TaskRuns {
+		if cc, ok := trs.ConditionChecks[name]; ok {
+			return cc.Status
+		}
+	}
+	return nil
+}
+
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, manifestFile), data, 0644)
+}
+
+// writeArchive writes the files under dir to a gzipped tarball, in a
+// directory named prefix
+func writeArchive(archive, dir, prefix string) error {
+	out, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func exportLogs(ctx context.Context, opts *options.LogOptions, lr *LogReader) error {
+	if opts.OutputDir != "" && opts.Archive != "" {
+		return fmt.Errorf("only one of --output-dir and --archive can be used")
+	}
+
+	tkn := lr.Clients.Tekton
+	pr, err := tkn.TektonV1alpha1().PipelineRuns(lr.Ns).Get(lr.Run, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// the logs of a live run are exported once it completes
+	if !pr.IsDone() {
+		lr.Follow = true
+	}
+
+	dir, target := opts.OutputDir, opts.OutputDir
+	if opts.Archive != "" {
+		if dir, err = ioutil.TempDir("", "tkn-logs-"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		target = opts.Archive
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	logC, errC, err := lr.Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	exporter := NewLogExporter(dir)
+	if err := exporter.Write(opts.Stream, logC, errC); err != nil {
+		return fmt.Errorf("failed to write the logs to %s: %s", dir, err)
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("export of the logs of PipelineRun %s interrupted", lr.Run)
+	}
+
+	// refresh the run to get the final statuses of its tasks
+	if pr, err = tkn.TektonV1alpha1().PipelineRuns(lr.Ns).Get(lr.Run, metav1.GetOptions{}); err != nil {
+		return err
+	}
+
+	pl, err := tkn.TektonV1alpha1().Pipelines(lr.Ns).Get(pipelineName(pr), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline %s to write the manifest of the logs: %s", pipelineName(pr), err)
+	}
+	runs := trh.SortTasksBySpecOrder(pl.Spec.Tasks, pr.Status.TaskRuns)
+
+	if err := writeManifest(dir, exporter.Manifest(pr, runs)); err != nil {
+		return err
+	}
+
+	if opts.Archive != "" {
+		if err := writeArchive(opts.Archive, dir, pr.Name); err != nil {
+			return fmt.Errorf("failed to write archive %s: %s", opts.Archive, err)
+		}
+	}
+
+	fmt.Fprintf(opts.Stream.Out, "Logs of PipelineRun %s written to %s\n", pr.Name, target)
+	return nil
+}
@@ -0,0 +1,362 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/cmd/taskrun"
+	"github.com/tektoncd/cli/pkg/helper/pods/fake"
+	"github.com/tektoncd/cli/pkg/helper/pods/stream"
+	trh "github.com/tektoncd/cli/pkg/helper/taskrun"
+	"github.com/tektoncd/cli/pkg/test"
+	cb "github.com/tektoncd/cli/pkg/test/builder"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func exportTestData(t *testing.T) (pipelinetest.Clients, stream.NewStreamerFunc) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+		start        = clockwork.NewFakeClock().Now()
+	)
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns,
+			},
+		},
+	}
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("output-task-1", ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("output-task"),
+			),
+			tb.TaskRunStatus(
+				tb.PodName("output-task-pod"),
+				tb.TaskRunStartTime(start),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName("writefile-step"),
+					tb.StateTerminated(0),
+				),
+				tb.StepState(
+					cb.StepName("nop"),
+					tb.StateTerminated(0),
+				),
+			),
+		),
+		tb.TaskRun("read-task-1", ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("read-task"),
+			),
+			tb.TaskRunStatus(
+				tb.PodName("read-task-pod"),
+				tb.TaskRunStartTime(start),
+				tb.StatusCondition(apis.Condition{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Message: "readfile-step exited with code 1",
+				}),
+				tb.StepState(
+					cb.StepName("readfile-step"),
+					tb.StateTerminated(1),
+				),
+			),
+		),
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", pipelineName),
+			tb.PipelineRunSpec(pipelineName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: resources.ReasonFailed,
+				}),
+				tb.PipelineRunTaskRunsStatus("output-task-1", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "output-task",
+					Status:           &trs[0].Status,
+				}),
+				tb.PipelineRunTaskRunsStatus("read-task-1", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "read-task",
+					Status:           &trs[1].Status,
+				}),
+			),
+		),
+	}
+
+	pps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, ns,
+			tb.PipelineSpec(
+				tb.PipelineTask("output-task", "output-task"),
+				tb.PipelineTask("read-task", "read-task"),
+			),
+		),
+	}
+
+	p := []*corev1.Pod{
+		tb.Pod("output-task-pod", ns,
+			tb.PodSpec(
+				tb.PodContainer("writefile-step", "writefile-step:latest"),
+				tb.PodContainer("nop", "override-with-nop:latest"),
+			),
+		),
+		tb.Pod("read-task-pod", ns,
+			tb.PodSpec(
+				tb.PodContainer("readfile-step", "readfile-step:latest"),
+			),
+		),
+	}
+
+	logs := fake.Logs(
+		fake.Task("output-task-pod",
+			fake.Step("writefile-step", "wrote a file"),
+			fake.Step("nop", "Build successful"),
+		),
+		fake.Task("read-task-pod",
+			fake.Step("readfile-step", "no such file"),
+		),
+	)
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Pipelines: pps, TaskRuns: trs, Pods: p, Namespaces: nsList})
+	return cs, fake.Streamer(logs)
+}
+
+func checkExportedLogs(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	test.AssertOutput(t, "wrote a file\n", files["output-task/writefile-step.log"])
+	test.AssertOutput(t, "Build successful\n", files["output-task/nop.log"])
+	if _, ok := files["read-task/readfile-step.log"]; ok {
+		t.Error("Expected no logs for the failed task")
+	}
+
+	m := Manifest{}
+	if err := json.Unmarshal([]byte(files[manifestFile]), &m); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, "output-pipeline-1", m.PipelineRun.Name)
+	test.AssertOutput(t, "PipelineRun", m.PipelineRun.Kind)
+	test.AssertOutput(t, "output-pipeline", m.PipelineRun.Spec.PipelineRef.Name)
+	test.AssertOutput(t, 2, len(m.PipelineRun.Status.TaskRuns))
+	test.AssertOutput(t, []string{
+		"output-task/nop.log",
+		"output-task/writefile-step.log",
+	}, m.Files)
+	test.AssertOutput(t, []string{"task read-task has failed: readfile-step exited with code 1"}, m.Errors)
+
+	test.AssertOutput(t, 2, len(m.Tasks))
+	test.AssertOutput(t, "output-task", m.Tasks[0].Task)
+	test.AssertOutput(t, "Succeeded", m.Tasks[0].Status)
+	test.AssertOutput(t, "output-task/writefile-step.log", m.Tasks[0].Steps[0].File)
+	test.AssertOutput(t, "read-task", m.Tasks[1].Task)
+	test.AssertOutput(t, "Failed", m.Tasks[1].Status)
+	test.AssertOutput(t, "readfile-step", m.Tasks[1].Steps[0].Step)
+	test.AssertOutput(t, "", m.Tasks[1].Steps[0].File)
+	test.AssertOutput(t, int32(1), *m.Tasks[1].Steps[0].ExitCode)
+}
+
+func TestLog_export_output_dir(t *testing.T) {
+	cs, streamer := exportTestData(t)
+
+	dir, err := ioutil.TempDir("", "tkn-logs-test-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	lo := logOpts("output-pipeline-1", "namespace", cs, streamer, false, false)
+	lo.OutputDir = filepath.Join(dir, "logs")
+	output, err := fetchLogs(lo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "task read-task has failed: readfile-step exited with code 1\n" +
+		"Logs of PipelineRun output-pipeline-1 written to " + lo.OutputDir + "\n"
+	test.AssertOutput(t, expected, output)
+
+	files := map[string]string{}
+	err = filepath.Walk(lo.OutputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(lo.OutputDir, path)
+		files[rel] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	checkExportedLogs(t, files)
+}
+
+func TestLog_export_archive(t *testing.T) {
+	cs, streamer := exportTestData(t)
+
+	dir, err := ioutil.TempDir("", "tkn-logs-test-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	lo := logOpts("output-pipeline-1", "namespace", cs, streamer, false, false)
+	lo.Archive = filepath.Join(dir, "run.tar.gz")
+	if _, err := fetchLogs(lo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	f, err := os.Open(lo.Archive)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		rel, err := filepath.Rel("output-pipeline-1", hdr.Name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		files[rel] = string(data)
+	}
+
+	checkExportedLogs(t, files)
+}
+
+func TestLog_export_output_dir_and_archive(t *testing.T) {
+	cs, streamer := exportTestData(t)
+
+	lo := logOpts("output-pipeline-1", "namespace", cs, streamer, false, false)
+	lo.OutputDir = "logs"
+	lo.Archive = "run.tar.gz"
+	_, err := fetchLogs(lo)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	test.AssertOutput(t, "only one of --output-dir and --archive can be used", err.Error())
+}
+
+func TestLogExporter_attempts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tkn-logs-test-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	step := func(typ taskrun.LogType, attempt int, msg string) Log {
+		return Log{Type: typ, Task: "build", TaskRun: "build-1", Step: "compile", Attempt: attempt, Log: msg}
+	}
+
+	e := NewLogExporter(dir)
+	for _, l := range []Log{
+		step(taskrun.StepStart, 1, ""),
+		step(taskrun.LogLine, 1, "out of memory"),
+		step(taskrun.StepEnd, 1, ""),
+		step(taskrun.StepStart, 2, ""),
+		step(taskrun.LogLine, 2, "compiled"),
+	} {
+		if err := e.write(l); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	test.AssertOutput(t, 1, len(e.files))
+	if err := e.write(step(taskrun.StepEnd, 2, "")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 0, len(e.files))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "build", "attempt-1", "compile.log"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "out of memory\n", string(data))
+
+	retried := v1alpha1.TaskRunStatus{}
+	retried.Steps = []v1alpha1.StepState{{Name: "compile"}}
+	status := v1alpha1.TaskRunStatus{}
+	status.Steps = []v1alpha1.StepState{{Name: "compile"}}
+	status.RetriesStatus = []v1alpha1.TaskRunStatus{retried}
+
+	pr := tb.PipelineRun("build-run", "ns",
+		tb.PipelineRunSpec("build-pipeline"),
+		tb.PipelineRunStatus(
+			tb.PipelineRunTaskRunsStatus("build-1", &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "build",
+				Status:           &status,
+			}),
+		),
+	)
+
+	m := e.Manifest(pr, []trh.Run{{Name: "build-1", Task: "build"}})
+	test.AssertOutput(t, "build-pipeline", m.PipelineRun.Spec.PipelineRef.Name)
+	test.AssertOutput(t, []string{
+		"build/attempt-1/compile.log",
+		"build/attempt-2/compile.log",
+	}, m.Files)
+	test.AssertOutput(t, []StepManifest{
+		{Step: "compile", Attempt: 1, File: "build/attempt-1/compile.log"},
+		{Step: "compile", Attempt: 2, File: "build/attempt-2/compile.log"},
+	}, m.Tasks[0].Steps)
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// interleaveWindow is how long the lines of live logs are held to be
+// reordered with the lines of the other tasks
+const interleaveWindow = time.Second
+
+// mergeLogs writes the logs of the sources to logC in the order of their
+// timestamps, the sources being each ordered. Logs without timestamp, like
+// the step events, are written as soon as they are read as they follow
+// the previous log of their source. At most max sources are read at the
+// same time, the next source being opened once one of them is done, so the
+// sources should come in the order they started. Merging stops once ctx is
+// done.
+func mergeLogs(ctx context.Context, sources []func() <-chan Log, max int, logC chan<- Log) {
+	active := []<-chan Log{}
+	heads := []*Log{}
+	opened := 0
+
+	for {
+		// drop the sources which are done and open the next ones
+		for i := 0; i < len(active); {
+			if active[i] == nil {
+				active = append(active[:i], active[i+1:]...)
+				heads = append(heads[:i], heads[i+1:]...)
+				continue
+			}
+			i++
+		}
+		for len(active) < max && opened < len(sources) {
+			active = append(active, sources[opened]())
+			heads = append(heads, nil)
+			opened++
+		}
+
+		next, closed := -1, false
+		for i, src := range active {
+			if heads[i] == nil && src != nil {
+				select {
+				case l, ok := <-src:
+					if !ok {
+						active[i] = nil
+						closed = true
+						continue
+					}
+					heads[i] = &l
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if heads[i] == nil {
+				continue
+			}
+
+			if heads[i].Timestamp.IsZero() {
+				next = i
+				break
+			}
+
+			if next == -1 || heads[i].Timestamp.Before(heads[next].Timestamp) {
+				next = i
+			}
+		}
+
+		// the source opened in place of a closed one may have the next log
+		if closed && opened < len(sources) {
+			continue
+		}
+		if next == -1 {
+			return
+		}
+
+		if !sendLog(ctx, logC, *heads[next]) {
+			return
+		}
+		heads[next] = nil
+	}
+}
+
+type pendingLog struct {
+	log     Log
+	key     time.Time
+	seq     int
+	arrival time.Time
+}
+
+type logHeap []*pendingLog
+
+func (h logHeap) Len() int { return len(h) }
+
+func (h logHeap) Less(i, j int) bool {
+	if h[i].key.Equal(h[j].key) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].key.Before(h[j].key)
+}
+
+func (h logHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *logHeap) Push(x interface{}) { *h = append(*h, x.(*pendingLog)) }
+
+func (h *logHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// reorderLogs writes the logs read from in to logC ordered by timestamp,
+// holding each of them for window so that the lines of the tasks running
+// in parallel can be put in order. Logs without timestamp are ordered
+// after the previous log of their TaskRun. The logs held are dropped once
+// ctx is done.
+func reorderLogs(ctx context.Context, in <-chan Log, logC chan<- Log, window time.Duration) {
+	var (
+		pending = &logHeap{}
+		last    = map[string]time.Time{}
+		seq     = 0
+		ticker  = time.NewTicker(window / 4)
+	)
+	defer ticker.Stop()
+
+	flush := func(all bool) bool {
+		now := time.Now()
+		for pending.Len() > 0 {
+			p := (*pending)[0]
+			if !all && now.Sub(p.arrival) < window {
+				return true
+			}
+			heap.Pop(pending)
+			if !sendLog(ctx, logC, p.log) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for in != nil {
+		select {
+		case <-ctx.Done():
+			return
+
+		case l, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+
+			now := time.Now()
+			key := l.Timestamp
+			if key.IsZero() {
+				if key = last[l.TaskRun]; key.IsZero() {
+					key = now
+				}
+			}
+			last[l.TaskRun] = key
+
+			seq++
+			heap.Push(pending, &pendingLog{log: l, key: key, seq: seq, arrival: now})
+
+		case <-ticker.C:
+			if !flush(false) {
+				return
+			}
+		}
+	}
+
+	flush(true)
+}
@@ -0,0 +1,232 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/cli/pkg/cmd/taskrun"
+	"github.com/tektoncd/cli/pkg/test"
+)
+
+func logAt(taskRun string, seconds int, msg string) Log {
+	return Log{
+		Type:      taskrun.LogLine,
+		TaskRun:   taskRun,
+		Timestamp: time.Date(2019, 12, 2, 10, 0, seconds, 0, time.UTC),
+		Log:       msg,
+	}
+}
+
+func stepEnd(taskRun string) Log {
+	return Log{Type: taskrun.StepEnd, TaskRun: taskRun}
+}
+
+func sendLogs(logs ...Log) <-chan Log {
+	c := make(chan Log)
+	go func() {
+		defer close(c)
+		for _, l := range logs {
+			c <- l
+		}
+	}()
+	return c
+}
+
+// opened returns the sources opening the channels
+func opened(chans ...<-chan Log) []func() <-chan Log {
+	sources := []func() <-chan Log{}
+	for _, c := range chans {
+		c := c
+		sources = append(sources, func() <-chan Log { return c })
+	}
+	return sources
+}
+
+func collect(logC <-chan Log) []string {
+	got := []string{}
+	for l := range logC {
+		if l.Type == taskrun.StepEnd {
+			got = append(got, l.TaskRun+" end")
+			continue
+		}
+		got = append(got, l.TaskRun+" "+l.Log)
+	}
+	return got
+}
+
+func TestMergeLogs(t *testing.T) {
+	sources := opened(
+		sendLogs(logAt("a", 1, "one"), logAt("a", 4, "four"), stepEnd("a")),
+		sendLogs(logAt("b", 2, "two"), logAt("b", 3, "three"), logAt("b", 5, "five"), stepEnd("b")),
+		sendLogs(),
+	)
+
+	logC := make(chan Log)
+	go func() {
+		defer close(logC)
+		mergeLogs(context.Background(), sources, len(sources), logC)
+	}()
+
+	test.AssertOutput(t, []string{
+		"a one",
+		"b two",
+		"b three",
+		"a four",
+		"a end",
+		"b five",
+		"b end",
+	}, collect(logC))
+}
+
+func TestMergeLogs_cancel(t *testing.T) {
+	sources := opened(
+		sendLogs(logAt("a", 1, "one"), logAt("a", 4, "four")),
+		sendLogs(logAt("b", 2, "two")),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// nothing reads the merged logs, merging must stop with ctx
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mergeLogs(ctx, sources, len(sources), make(chan Log))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the merge to stop once the context is cancelled")
+	}
+}
+
+func TestMergeLogs_cancel_quiet_source(t *testing.T) {
+	// the second source never sends anything nor is closed
+	sources := opened(
+		sendLogs(logAt("a", 1, "one")),
+		make(chan Log),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logC := make(chan Log)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mergeLogs(ctx, sources, len(sources), logC)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the merge to stop once the context is cancelled")
+	}
+}
+
+func TestMergeLogs_max(t *testing.T) {
+	logs := [][]Log{
+		{logAt("a", 1, "one"), logAt("a", 5, "five")},
+		{logAt("b", 2, "two"), logAt("b", 3, "three")},
+		{logAt("c", 4, "four")},
+	}
+
+	// the sources are only opened when one of the two read is done
+	var open int32
+	sources := []func() <-chan Log{}
+	for _, l := range logs {
+		l := l
+		sources = append(sources, func() <-chan Log {
+			if atomic.AddInt32(&open, 1) > 2 {
+				t.Errorf("Expected at most 2 sources to be read at the same time")
+			}
+			c := make(chan Log)
+			go func() {
+				defer close(c)
+				defer atomic.AddInt32(&open, -1)
+				for _, log := range l {
+					c <- log
+				}
+			}()
+			return c
+		})
+	}
+
+	logC := make(chan Log)
+	go func() {
+		defer close(logC)
+		mergeLogs(context.Background(), sources, 2, logC)
+	}()
+
+	test.AssertOutput(t, []string{
+		"a one",
+		"b two",
+		"b three",
+		"c four",
+		"a five",
+	}, collect(logC))
+}
+
+func TestReorderLogs(t *testing.T) {
+	in := make(chan Log)
+	go func() {
+		defer close(in)
+		in <- logAt("b", 2, "two")
+		in <- logAt("a", 1, "one")
+		in <- stepEnd("a")
+		in <- logAt("a", 3, "three")
+	}()
+
+	logC := make(chan Log)
+	go func() {
+		defer close(logC)
+		reorderLogs(context.Background(), in, logC, time.Minute)
+	}()
+
+	test.AssertOutput(t, []string{
+		"a one",
+		"a end",
+		"b two",
+		"a three",
+	}, collect(logC))
+}
+
+func TestReorderLogs_window(t *testing.T) {
+	in := make(chan Log)
+	go func() {
+		defer close(in)
+		in <- logAt("b", 2, "two")
+		// a line later than the window is not reordered
+		time.Sleep(100 * time.Millisecond)
+		in <- logAt("a", 1, "one")
+	}()
+
+	logC := make(chan Log)
+	go func() {
+		defer close(logC)
+		reorderLogs(context.Background(), in, logC, 20*time.Millisecond)
+	}()
+
+	test.AssertOutput(t, []string{
+		"b two",
+		"a one",
+	}, collect(logC))
+}
@@ -15,7 +15,9 @@
 package pipelinerun
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,6 +25,7 @@ import (
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/cmd/taskrun"
 	"github.com/tektoncd/cli/pkg/helper/pipelinerun"
+	"github.com/tektoncd/cli/pkg/helper/pods"
 	"github.com/tektoncd/cli/pkg/helper/pods/stream"
 	trh "github.com/tektoncd/cli/pkg/helper/taskrun"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -40,17 +43,67 @@ type LogReader struct {
 	AllSteps bool
 	Follow   bool
 	Tasks    []string
+	// Steps restricts the logs to the steps with these names
+	Steps []string
+	// Sidecars adds the logs of the sidecars of the tasks
+	Sidecars bool
+	// Attempt selects the attempts of the TaskRuns whose logs are read
+	Attempt int
+	// LogOptions restricts the logs read from each step
+	LogOptions pods.LogOptions
+	// Interleave merges the logs of the tasks in the order of their
+	// timestamps, which must be requested with LogOptions
+	Interleave bool
+	// MaxParallel is the maximum number of tasks whose logs are followed
+	// at the same time, DefaultMaxParallel when 0
+	MaxParallel int
+	// NoWait reads the logs available so far of a running PipelineRun
+	// instead of waiting for it to complete
+	NoWait bool
 }
 
+// DefaultMaxParallel is the number of tasks whose logs are followed at the
+// same time by default
+const DefaultMaxParallel = 16
+
 // Log is the data gets written to the log channel
 type Log struct {
-	Pipeline string
-	Task     string
-	Step     string
-	Log      string
+	Type        taskrun.LogType
+	Pipeline    string
+	PipelineRun string
+	Task        string
+	TaskRun     string
+	Step        string
+	Container   string
+	Pod         string
+	Timestamp   time.Time
+	Log         string
+	ExitCode    *int32
+	Attempt     int
+}
+
+// Record returns the JSON record of the log
+func (l Log) Record() taskrun.LogRecord {
+	r := taskrun.LogRecord{
+		Type:        string(l.Type),
+		Pipeline:    l.Pipeline,
+		PipelineRun: l.PipelineRun,
+		Task:        l.Task,
+		TaskRun:     l.TaskRun,
+		Step:        l.Step,
+		Container:   l.Container,
+		Pod:         l.Pod,
+		Message:     l.Log,
+		ExitCode:    l.ExitCode,
+		Attempt:     l.Attempt,
+	}
+	r.SetTimestamp(l.Timestamp)
+	return r
 }
 
-func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
+// Read streams the logs of the PipelineRun until they are all read or ctx
+// is done, the channels being closed in both cases
+func (lr *LogReader) Read(ctx context.Context) (<-chan Log, <-chan error, error) {
 	tkn := lr.Clients.Tekton
 	pr, err := tkn.TektonV1alpha1().PipelineRuns(lr.Ns).Get(lr.Run, metav1.GetOptions{})
 	if err != nil {
@@ -58,13 +111,23 @@ func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
 	}
 
 	if lr.Follow {
-		return lr.readLiveLogs(pr)
+		logC, errC, err := lr.readLiveLogs(ctx, pr)
+		if err != nil || !lr.Interleave {
+			return logC, errC, err
+		}
+
+		orderedC := make(chan Log)
+		go func() {
+			defer close(orderedC)
+			reorderLogs(ctx, logC, orderedC, interleaveWindow)
+		}()
+		return orderedC, errC, nil
 	}
-	return lr.readAvailableLogs(pr)
+	return lr.readAvailableLogs(ctx, pr)
 
 }
 
-func (lr *LogReader) readLiveLogs(pr *v1alpha1.PipelineRun) (<-chan Log, <-chan error, error) {
+func (lr *LogReader) readLiveLogs(ctx context.Context, pr *v1alpha1.PipelineRun) (<-chan Log, <-chan error, error) {
 	logC := make(chan Log)
 	errC := make(chan error)
 
@@ -73,22 +136,33 @@ func (lr *LogReader) readLiveLogs(pr *v1alpha1.PipelineRun) (<-chan Log, <-chan
 		defer close(errC)
 
 		prTracker := pipelinerun.NewTracker(pr.Name, lr.Ns, lr.Clients.Tekton)
-		trC := prTracker.Monitor(lr.Tasks)
+		trC := prTracker.Monitor(ctx, lr.Tasks)
+
+		sem := make(chan struct{}, lr.maxParallel())
 
 		wg := sync.WaitGroup{}
 		taskIndex := int32(1)
 
 		for trs := range trC {
-			wg.Add(len(trs))
-
 			for _, run := range trs {
+				// the tasks wait for the logs of the previous ones to be
+				// read when too many are running
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					continue
+				}
+
+				wg.Add(1)
 				// NOTE: passing tr, taskIdx to avoid data race
 				go func(tr trh.Run, taskNum int32) {
-					defer wg.Done()
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
 
-					tlr := tr.NewLogReader(lr.Ns, lr.Clients, lr.Streamer,
-						int(taskNum), lr.Follow, lr.AllSteps)
-					pipeLogs(logC, errC, tlr)
+					tlr := lr.taskRunLogReader(tr, int(taskNum))
+					pipeLogs(ctx, logC, errC, pr, tlr)
 				}(run, atomic.AddInt32(&taskIndex, 1))
 			}
 		}
@@ -96,16 +170,18 @@ func (lr *LogReader) readLiveLogs(pr *v1alpha1.PipelineRun) (<-chan Log, <-chan
 		wg.Wait()
 
 		if !empty(pr.Status) && pr.Status.Conditions[0].Status == corev1.ConditionFalse {
-			errC <- fmt.Errorf(pr.Status.Conditions[0].Message)
+			sendError(ctx, errC, fmt.Errorf(pr.Status.Conditions[0].Message))
 		}
 	}()
 
 	return logC, errC, nil
 }
 
-func (lr *LogReader) readAvailableLogs(pr *v1alpha1.PipelineRun) (<-chan Log, <-chan error, error) {
-	if err := lr.waitUntilAvailable(10); err != nil {
-		return nil, nil, err
+func (lr *LogReader) readAvailableLogs(ctx context.Context, pr *v1alpha1.PipelineRun) (<-chan Log, <-chan error, error) {
+	if !lr.NoWait {
+		if err := lr.waitUntilAvailable(ctx, 10); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	tkn := lr.Clients.Tekton
@@ -125,27 +201,104 @@ func (lr *LogReader) readAvailableLogs(pr *v1alpha1.PipelineRun) (<-chan Log, <-
 		defer close(logC)
 		defer close(errC)
 
-		for i, tr := range taskRuns {
-			tlr := tr.NewLogReader(
-				lr.Ns, lr.Clients, lr.Streamer,
-				i+1, lr.Follow, lr.AllSteps)
-
-			pipeLogs(logC, errC, tlr)
+		if lr.Interleave {
+			lr.mergeTaskRunsLogs(ctx, logC, errC, pr, taskRuns)
+		} else {
+			for i, tr := range taskRuns {
+				tlr := lr.taskRunLogReader(tr, i+1)
+				pipeLogs(ctx, logC, errC, pr, tlr)
+			}
 		}
 
 		if !empty(pr.Status) && pr.Status.Conditions[0].Status == corev1.ConditionFalse {
-			errC <- fmt.Errorf(pr.Status.Conditions[0].Message)
+			sendError(ctx, errC, fmt.Errorf(pr.Status.Conditions[0].Message))
 		}
 	}()
 
 	return logC, errC, nil
 }
 
+// mergeTaskRunsLogs reads the logs of the TaskRuns, at most MaxParallel of
+// them at the same time in the order they started, and merges them in the
+// order of their timestamps. It returns once all the TaskRuns are read, so
+// that nothing is sent to errC after it is closed.
+func (lr *LogReader) mergeTaskRunsLogs(ctx context.Context, logC chan<- Log, errC chan<- error, pr *v1alpha1.PipelineRun, taskRuns []trh.Run) {
+	num := map[string]int{}
+	for i, tr := range taskRuns {
+		num[tr.Name] = i + 1
+	}
+
+	started := append([]trh.Run{}, taskRuns...)
+	sort.SliceStable(started, func(i, j int) bool {
+		return startedBefore(startTime(pr, started[i]), startTime(pr, started[j]))
+	})
+
+	wg := sync.WaitGroup{}
+	sources := make([]func() <-chan Log, len(started))
+	for i, tr := range started {
+		tlr := lr.taskRunLogReader(tr, num[tr.Name])
+		sources[i] = func() <-chan Log {
+			tlogC := make(chan Log)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(tlogC)
+				pipeLogs(ctx, tlogC, errC, pr, tlr)
+			}()
+			return tlogC
+		}
+	}
+
+	mergeLogs(ctx, sources, lr.maxParallel(), logC)
+	wg.Wait()
+}
+
+func (lr *LogReader) maxParallel() int {
+	if lr.MaxParallel <= 0 {
+		return DefaultMaxParallel
+	}
+	return lr.MaxParallel
+}
+
+// startTime returns when the TaskRun, or the condition check, started
+func startTime(pr *v1alpha1.PipelineRun, r trh.Run) *metav1.Time {
+	for name, trs := range pr.Status.TaskRuns {
+		if name == r.Name && trs.Status != nil {
+			return trs.Status.StartTime
+		}
+		if cc, ok := trs.ConditionChecks[r.Name]; ok && cc.Status != nil {
+			return cc.Status.StartTime
+		}
+	}
+	return nil
+}
+
+// startedBefore orders the start times, the runs which did not start coming
+// last
+func startedBefore(a, b *metav1.Time) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return a.Before(b)
+}
+
+func (lr *LogReader) taskRunLogReader(tr trh.Run, num int) *taskrun.LogReader {
+	tlr := tr.NewLogReader(lr.Ns, lr.Clients, lr.Streamer,
+		num, lr.Follow, lr.AllSteps, lr.LogOptions)
+	tlr.Steps = lr.Steps
+	tlr.Sidecars = lr.Sidecars
+	tlr.Attempt = lr.Attempt
+	return tlr
+}
+
 // reading of logs should wait till the status of run is unknown
 // only if run status is unknown, open a watch channel on run
 // and keep checking the status until it changes to true|false
 // or the reach timeout
-func (lr *LogReader) waitUntilAvailable(timeout time.Duration) error {
+func (lr *LogReader) waitUntilAvailable(ctx context.Context, timeout time.Duration) error {
 	var first = true
 	opts := metav1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector("metadata.name", lr.Run).String(),
@@ -168,6 +321,9 @@ func (lr *LogReader) waitUntilAvailable(timeout time.Duration) error {
 	}
 	for {
 		select {
+		case <-ctx.Done():
+			watchRun.Stop()
+			return ctx.Err()
 		case event := <-watchRun.ResultChan():
 			if event.Object.(*v1alpha1.PipelineRun).IsDone() {
 				watchRun.Stop()
@@ -185,10 +341,24 @@ func (lr *LogReader) waitUntilAvailable(timeout time.Duration) error {
 	}
 }
 
-func pipeLogs(logC chan<- Log, errC chan<- error, tlr *taskrun.LogReader) {
-	tlogC, terrC, err := tlr.Read()
+// TaskRunError reports a failure to read the logs of a TaskRun of the
+// PipelineRun
+type TaskRunError struct {
+	Task    string
+	TaskRun string
+	Err     error
+}
+
+func (e *TaskRunError) Error() string {
+	return fmt.Sprintf("failed to get logs for task %s : %s", e.Task, e.Err)
+}
+
+// pipeLogs sends the logs of the TaskRun read by tlr until they are all
+// read or ctx is done
+func pipeLogs(ctx context.Context, logC chan<- Log, errC chan<- error, pr *v1alpha1.PipelineRun, tlr *taskrun.LogReader) {
+	tlogC, terrC, err := tlr.Read(ctx)
 	if err != nil {
-		errC <- err
+		sendError(ctx, errC, err)
 		return
 	}
 
@@ -199,18 +369,64 @@ func pipeLogs(logC chan<- Log, errC chan<- error, tlr *taskrun.LogReader) {
 				tlogC = nil
 				continue
 			}
-			logC <- Log{Task: l.Task, Step: l.Step, Log: l.Log}
+			log := Log{
+				Type:        l.Type,
+				Pipeline:    pipelineName(pr),
+				PipelineRun: pr.Name,
+				Task:        l.Task,
+				TaskRun:     l.TaskRun,
+				Step:        l.Step,
+				Container:   l.Container,
+				Pod:         l.Pod,
+				Timestamp:   l.Timestamp,
+				Log:         l.Log,
+				ExitCode:    l.ExitCode,
+				Attempt:     l.Attempt,
+			}
+			if !sendLog(ctx, logC, log) {
+				return
+			}
 
 		case e, ok := <-terrC:
 			if !ok {
 				terrC = nil
 				continue
 			}
-			errC <- fmt.Errorf("failed to get logs for task %s : %s", tlr.Task, e)
+			if !sendError(ctx, errC, &TaskRunError{Task: tlr.Task, TaskRun: tlr.Run, Err: e}) {
+				return
+			}
 		}
 	}
 }
 
+// sendLog sends l unless ctx is done first, it returns whether it was sent
+func sendLog(ctx context.Context, logC chan<- Log, l Log) bool {
+	select {
+	case logC <- l:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sendError sends err unless ctx is done first, it returns whether it was
+// sent
+func sendError(ctx context.Context, errC chan<- error, err error) bool {
+	select {
+	case errC <- err:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func pipelineName(pr *v1alpha1.PipelineRun) string {
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		return pr.Spec.PipelineRef.Name
+	}
+	return pr.Labels["tekton.dev/pipeline"]
+}
+
 func empty(status v1alpha1.PipelineRunStatus) bool {
 
 	if status.Conditions == nil {
@@ -0,0 +1,116 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/cli/pkg/cmd/taskrun"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	prsort "github.com/tektoncd/cli/pkg/helper/pipelinerun/sort"
+	"github.com/tektoncd/cli/pkg/helper/pods"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SearchLogs looks for the lines matching opts.Grep in the logs of the
+// last opts.Runs runs of pipeline opts.PipelineName, until ctx is done
+func SearchLogs(ctx context.Context, opts *options.LogOptions) error {
+	pattern, err := opts.GrepPattern()
+	if err != nil {
+		return err
+	}
+
+	logOpts, err := opts.PodLogOptions()
+	if err != nil {
+		return err
+	}
+
+	cs, err := opts.Params.Clients()
+	if err != nil {
+		return err
+	}
+
+	ns := opts.Params.Namespace()
+	prs, err := cs.Tekton.TektonV1alpha1().PipelineRuns(ns).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("tekton.dev/pipeline=%s", opts.PipelineName),
+	})
+	if err != nil {
+		return err
+	}
+
+	runs := map[string]*v1alpha1.PipelineRun{}
+	names := []string{}
+	sorted := prsort.SortPipelineRunsByStartTime(prs.Items)
+	for i, pr := range sorted {
+		if len(names) == opts.Runs {
+			break
+		}
+		if !opts.HasStatus(pr.Status.Conditions) {
+			continue
+		}
+		runs[pr.Name] = &sorted[i]
+		names = append(names, pr.Name)
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintln(opts.Stream.Err, "No pipelineruns found for pipeline:", opts.PipelineName)
+		return nil
+	}
+
+	streamer := opts.Streamer
+	if streamer == nil {
+		streamer = pods.NewStream
+	}
+
+	results := taskrun.Search(ctx, names, opts.Parallel, func(name string) *taskrun.SearchResult {
+		pr := runs[name]
+		r := &taskrun.SearchResult{
+			Run:       name,
+			Status:    formatted.Condition(pr.Status.Conditions),
+			StartTime: pr.Status.StartTime,
+		}
+
+		lr := &LogReader{
+			Run:        name,
+			Ns:         ns,
+			Clients:    cs,
+			Streamer:   streamer,
+			Stream:     opts.Stream,
+			AllSteps:   opts.AllSteps,
+			Tasks:      opts.Tasks,
+			LogOptions: logOpts,
+			NoWait:     true,
+		}
+
+		logC, errC, err := lr.Read(ctx)
+		if err != nil {
+			r.AddError(err)
+			return r
+		}
+
+		r.Scan(pattern, taskrun.Records(ctx, func() (taskrun.LogRecord, bool) {
+			l, ok := <-logC
+			return l.Record(), ok
+		}), errC)
+		return r
+	})
+
+	w := taskrun.NewSearchWriter(pattern, opts.Params.Time())
+	w.Interrupted = ctx.Err() != nil
+	return w.Write(opts.Stream, results)
+}
@@ -16,6 +16,8 @@ package pipelinerun
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -302,6 +304,7 @@ func TestPipelinerunLogs(t *testing.T) {
 		name         string
 		allSteps     bool
 		tasks        []string
+		steps        []string
 		expectedLogs []string
 	}{
 		{
@@ -332,6 +335,13 @@ func TestPipelinerunLogs(t *testing.T) {
 				"[read-task : readfile-step] able to read a file\n",
 				"[read-task : nop] Build successful\n",
 			},
+		}, {
+			name:  "for selected steps",
+			steps: []string{"writefile-step", "readfile-step"},
+			expectedLogs: []string{
+				"[output-task : writefile-step] written a file\n",
+				"[read-task : readfile-step] able to read a file\n",
+			},
 		},
 	}
 
@@ -340,6 +350,7 @@ func TestPipelinerunLogs(t *testing.T) {
 			cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Pipelines: pps, TaskRuns: trs, Pods: p, Namespaces: nsList})
 
 			prlo := logOpts(prName, ns, cs, fake.Streamer(fakeLogs), s.allSteps, false, s.tasks...)
+			prlo.Steps = s.steps
 			output, _ := fetchLogs(prlo)
 
 			expected := strings.Join(s.expectedLogs, "\n") + "\n"
@@ -465,6 +476,136 @@ func TestPipelinerunLog_completed_taskrun_only(t *testing.T) {
 	test.AssertOutput(t, expected, output)
 }
 
+func TestPipelinerunLog_condition_checks(t *testing.T) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+
+		task1Name = "output-task"
+		tr1Name   = "output-task-1"
+		cc1Name   = "output-task-1-file-exists"
+		cc1Pod    = "output-task-1-file-exists-pod"
+
+		task2Name = "read-task"
+		tr2Name   = "read-task-1"
+		tr2Pod    = "read-task-pod-123456"
+	)
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns,
+			},
+		},
+	}
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(cc1Name, ns,
+			tb.TaskRunStatus(
+				tb.PodName(cc1Pod),
+				tb.TaskRunStartTime(time.Now()),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+				}),
+			),
+		),
+		tb.TaskRun(tr2Name, ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(task2Name),
+			),
+			tb.TaskRunStatus(
+				tb.PodName(tr2Pod),
+				tb.TaskRunStartTime(time.Now()),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+		),
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", prName),
+			tb.PipelineRunSpec(pipelineName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Status: corev1.ConditionTrue,
+					Reason: resources.ReasonSucceeded,
+				}),
+				tb.PipelineRunTaskRunsStatus(tr1Name, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: task1Name,
+					ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+						cc1Name: {
+							ConditionName: "file-exists",
+							Status:        &v1alpha1.ConditionCheckStatus{PodName: cc1Pod},
+						},
+					},
+				}),
+				tb.PipelineRunTaskRunsStatus(tr2Name, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: task2Name,
+					Status:           &trs[1].Status,
+				}),
+			),
+		),
+	}
+
+	pps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, ns,
+			tb.PipelineSpec(
+				tb.PipelineTask(task1Name, task1Name,
+					tb.PipelineTaskCondition("file-exists"),
+				),
+				tb.PipelineTask(task2Name, task2Name),
+			),
+		),
+	}
+
+	p := []*corev1.Pod{
+		tb.Pod(cc1Pod, ns,
+			tb.PodSpec(
+				tb.PodContainer("step-condition-check-file-exists", "alpine:latest"),
+			),
+		),
+		tb.Pod(tr2Pod, ns,
+			tb.PodSpec(
+				tb.PodContainer("step-readfile", "alpine:latest"),
+			),
+		),
+	}
+	p[0].Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: "step-condition-check-file-exists",
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+		},
+	}}
+
+	fakeLogs := fake.Logs(
+		fake.Task(cc1Pod,
+			fake.Step("step-condition-check-file-exists", "no such file: README.md"),
+		),
+		fake.Task(tr2Pod,
+			fake.Step("step-readfile", "able to read a file"),
+		),
+	)
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Pipelines: pps, TaskRuns: trs, Pods: p, Namespaces: nsList})
+	prlo := logOpts(prName, ns, cs, fake.Streamer(fakeLogs), false, false)
+	output, err := fetchLogs(prlo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "[output-task : condition/file-exists] no such file: README.md\n" +
+		"[output-task : condition/file-exists] condition check failed\n" +
+		"\n" +
+		"[read-task : readfile] able to read a file\n" +
+		"\n"
+	test.AssertOutput(t, expected, output)
+}
+
 func TestPipelinerunLog_follow_mode(t *testing.T) {
 	var (
 		pipelineName = "output-pipeline"
@@ -731,6 +872,16 @@ func TestLog_run_failed_with_and_without_follow(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 	test.AssertOutput(t, failMessage+"\n", output)
+
+	// json output
+	prlo = logOpts(prName, ns, cs, fake.Streamer([]fake.Log{}), false, false)
+	prlo.Output = "json"
+	output, err = fetchLogs(prlo)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `{"type":"error","pipeline":"","pipelinerun":"fail-run","task":"","taskrun":"","step":"","container":"","pod":"","timestamp":null,"message":"Failed because I wanted"}` + "\n"
+	test.AssertOutput(t, expected, output)
 }
 
 func TestLog_pipeline_still_running(t *testing.T) {
@@ -871,6 +1022,242 @@ func TestLog_pipeline_status_done(t *testing.T) {
 	test.AssertOutput(t, "", output)
 }
 
+func TestPipelinerunLog_interleave(t *testing.T) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+		start        = clockwork.NewFakeClock().Now()
+	)
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns,
+			},
+		},
+	}
+
+	taskRun := func(name, task, pod string) *v1alpha1.TaskRun {
+		return tb.TaskRun(name, ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(task),
+			),
+			tb.TaskRunStatus(
+				tb.PodName(pod),
+				tb.TaskRunStartTime(start),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName("run"),
+					tb.StateTerminated(0),
+				),
+			),
+		)
+	}
+
+	trs := []*v1alpha1.TaskRun{
+		taskRun("server-1", "server", "server-pod"),
+		taskRun("client-1", "client", "client-pod"),
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", pipelineName),
+			tb.PipelineRunSpec(pipelineName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.PipelineRunTaskRunsStatus("server-1", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "server",
+					Status:           &trs[0].Status,
+				}),
+				tb.PipelineRunTaskRunsStatus("client-1", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "client",
+					Status:           &trs[1].Status,
+				}),
+			),
+		),
+	}
+
+	pps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, ns,
+			tb.PipelineSpec(
+				tb.PipelineTask("server", "server"),
+				tb.PipelineTask("client", "client"),
+			),
+		),
+	}
+
+	p := []*corev1.Pod{
+		tb.Pod("server-pod", ns,
+			tb.PodSpec(
+				tb.PodContainer("run", "server:latest"),
+			),
+		),
+		tb.Pod("client-pod", ns,
+			tb.PodSpec(
+				tb.PodContainer("run", "client:latest"),
+			),
+		),
+	}
+
+	fakeLogStream := fake.Logs(
+		fake.Task("server-pod",
+			fake.Step("run",
+				"2019-12-02T10:00:01Z listening on :8080",
+				"2019-12-02T10:00:03Z GET /health",
+				"2019-12-02T10:00:05Z shutting down",
+			),
+		),
+		fake.Task("client-pod",
+			fake.Step("run",
+				"2019-12-02T10:00:02Z connecting to server:8080",
+				"2019-12-02T10:00:04Z server is healthy",
+			),
+		),
+	)
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Pipelines: pps, TaskRuns: trs, Pods: p, Namespaces: nsList})
+	prlo := logOpts(prName, ns, cs, fake.Streamer(fakeLogStream), false, false)
+	prlo.Interleave = true
+	output, err := fetchLogs(prlo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "2019-12-02T10:00:01.000000000Z [server : run] listening on :8080\n" +
+		"2019-12-02T10:00:02.000000000Z [client : run] connecting to server:8080\n" +
+		"2019-12-02T10:00:03.000000000Z [server : run] GET /health\n" +
+		"2019-12-02T10:00:04.000000000Z [client : run] server is healthy\n" +
+		"2019-12-02T10:00:05.000000000Z [server : run] shutting down\n"
+
+	test.AssertOutput(t, expected, output)
+}
+
+func TestPipelinerunLog_interleave_cancel(t *testing.T) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+	)
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns,
+			},
+		},
+	}
+
+	trs := []*v1alpha1.TaskRun{}
+	tasks := []tb.PipelineSpecOp{}
+	statuses := []tb.PipelineRunStatusOp{}
+	pods := []*corev1.Pod{}
+	logs := []fake.Log{}
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("task-%d", i)
+		pod := name + "-pod"
+
+		trs = append(trs, tb.TaskRun(name+"-1", ns,
+			tb.TaskRunSpec(tb.TaskRunTaskRef(name)),
+			tb.TaskRunStatus(
+				tb.PodName(pod),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(cb.StepName("run"), tb.StateTerminated(0)),
+			),
+		))
+		tasks = append(tasks, tb.PipelineTask(name, name))
+		statuses = append(statuses, tb.PipelineRunTaskRunsStatus(name+"-1", &v1alpha1.PipelineRunTaskRunStatus{
+			PipelineTaskName: name,
+			Status:           &trs[i].Status,
+		}))
+
+		// the pods of half of the tasks are missing, so that errors are
+		// sent while the logs are read
+		if i%2 == 1 {
+			continue
+		}
+		pods = append(pods, tb.Pod(pod, ns, tb.PodSpec(tb.PodContainer("run", "busybox"))))
+		logs = append(logs, fake.Task(pod, fake.Step("run",
+			"2019-12-02T10:00:01Z one",
+			"2019-12-02T10:00:02Z two",
+			"2019-12-02T10:00:03Z three",
+		)))
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", pipelineName),
+			tb.PipelineRunSpec(pipelineName),
+			tb.PipelineRunStatus(append(statuses, tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}))...),
+		),
+	}
+
+	pps := []*v1alpha1.Pipeline{
+		tb.Pipeline(pipelineName, ns, tb.PipelineSpec(tasks...)),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Pipelines: pps, TaskRuns: trs, Pods: pods, Namespaces: nsList})
+
+	for i := 0; i < 20; i++ {
+		lr := &LogReader{
+			Run:        prName,
+			Ns:         ns,
+			Clients:    &cli.Clients{Tekton: cs.Pipeline, Kube: cs.Kube},
+			Streamer:   fake.Streamer(fake.Logs(logs...)),
+			Stream:     &cli.Stream{Out: new(bytes.Buffer), Err: new(bytes.Buffer)},
+			Interleave: true,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		logC, errC, err := lr.Read(ctx)
+		if err != nil {
+			cancel()
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// the errors of the missing pods are not read before cancelling, so
+		// that they are still being sent when the channels are closed
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for logC != nil || errC != nil {
+				select {
+				case _, ok := <-logC:
+					if !ok {
+						logC = nil
+					}
+				case _, ok := <-errC:
+					if !ok {
+						errC = nil
+					}
+				}
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("Expected the logs to stop once the context is cancelled")
+		}
+	}
+}
+
 func updatePR(finalRuns []*v1alpha1.PipelineRun, watcher *watch.FakeWatcher) {
 	go func() {
 		for _, pr := range finalRuns {
@@ -903,7 +1290,7 @@ func fetchLogs(lo *options.LogOptions) (string, error) {
 	out := new(bytes.Buffer)
 	lo.Stream = &cli.Stream{Out: out, Err: out}
 
-	err := Run(lo)
+	err := Run(context.Background(), lo)
 
 	return out.String(), err
 }
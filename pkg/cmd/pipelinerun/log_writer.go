@@ -18,10 +18,15 @@ import (
 	"fmt"
 
 	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/cmd/taskrun"
 	"github.com/tektoncd/cli/pkg/formatted"
 )
 
 type LogWriter struct {
+	// Interleaved logs of the tasks aren't separated by an empty line at
+	// the end of each step
+	Interleaved bool
+
 	fmt *formatted.Color
 }
 
@@ -41,12 +46,28 @@ func (lw *LogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
 				continue
 			}
 
-			if l.Log == "EOFLOG" {
-				fmt.Fprintf(s.Out, "\n")
+			switch l.Type {
+			case taskrun.StepStart:
+				continue
+			case taskrun.StepEnd:
+				if !lw.Interleaved {
+					fmt.Fprintf(s.Out, "\n")
+				}
+				continue
+			case taskrun.ConditionResult:
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s : %s] ", l.Task, l.Step)
+				fmt.Fprintf(s.Out, "condition check %s\n", l.Log)
 				continue
 			}
 
-			lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s : %s] ", l.Task, l.Step)
+			if !l.Timestamp.IsZero() {
+				fmt.Fprintf(s.Out, "%s ", formatted.Timestamp(l.Timestamp))
+			}
+			if l.Attempt > 0 {
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s (attempt %d) : %s] ", l.Task, l.Attempt, l.Step)
+			} else {
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s : %s] ", l.Task, l.Step)
+			}
 			fmt.Fprintf(s.Out, "%s\n", l.Log)
 		case e, ok := <-errC:
 			if !ok {
@@ -57,3 +78,38 @@ func (lw *LogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
 		}
 	}
 }
+
+// JSONLogWriter writes the logs, step events and errors as JSON lines on
+// the output stream
+type JSONLogWriter struct {
+	// PipelineRun is the name of the run reported in the records of errors
+	PipelineRun string
+}
+
+func (w *JSONLogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
+	for logC != nil || errC != nil {
+		select {
+		case l, ok := <-logC:
+			if !ok {
+				logC = nil
+				continue
+			}
+			if err := taskrun.WriteRecord(s.Out, l.Record()); err != nil {
+				fmt.Fprintf(s.Err, "failed to write log: %s\n", err)
+			}
+
+		case e, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			r := taskrun.LogRecord{Type: taskrun.ErrorRecord, PipelineRun: w.PipelineRun, Message: e.Error()}
+			if te, ok := e.(*TaskRunError); ok {
+				r.Task, r.TaskRun = te.Task, te.TaskRun
+			}
+			if err := taskrun.WriteRecord(s.Out, r); err != nil {
+				fmt.Fprintf(s.Err, "failed to write error: %s\n", err)
+			}
+		}
+	}
+}
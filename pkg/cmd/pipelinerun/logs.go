@@ -15,6 +15,7 @@
 package pipelinerun
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -40,6 +41,30 @@ Show the logs of PipelineRun named 'microservice-1' for task 'build' only from n
 Show the logs of PipelineRun named 'microservice-1' for all tasks and steps (including init steps) from namespace 'foo':
 
     tkn pr logs microservice-1 -a -n foo
+
+Show the logs of the last 10 minutes of PipelineRun named 'microservice-1' from namespace 'foo':
+
+    tkn pr logs microservice-1 --since 10m -n foo
+
+Show the logs of PipelineRun named 'microservice-1' as JSON lines from namespace 'foo':
+
+    tkn pr logs microservice-1 -o json -n foo
+
+Show the logs of all the attempts of the tasks of PipelineRun named 'microservice-1' which have been retried:
+
+    tkn pr logs microservice-1 --attempt all -n foo
+
+Show the logs of the tasks of PipelineRun named 'microservice-1' running in parallel merged in chronological order:
+
+    tkn pr logs microservice-1 --interleave -f -n foo
+
+Save the logs of PipelineRun named 'microservice-1' to one file per step under ./logs, along with a manifest.json:
+
+    tkn pr logs microservice-1 --output-dir ./logs -n foo
+
+Save the logs of PipelineRun named 'microservice-1' to a gzipped tarball:
+
+    tkn pr logs microservice-1 --archive run.tar.gz -n foo
    `
 
 	c := &cobra.Command{
@@ -64,20 +89,34 @@ Show the logs of PipelineRun named 'microservice-1' for all tasks and steps (inc
 				return err
 			}
 
-			return Run(opts)
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return Run(ctx, opts)
 		},
 	}
 
 	c.Flags().BoolVarP(&opts.AllSteps, "all", "a", false, "show all logs including init steps injected by tekton")
 	c.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "stream live logs")
+	c.Flags().StringSliceVarP(&opts.Steps, "step", "", []string{}, "show logs for mentioned steps only, can be repeated")
+	c.Flags().BoolVarP(&opts.Sidecars, "sidecars", "", false, "show logs of the sidecars after the ones of the steps")
+	c.Flags().StringVarP(&opts.Attempt, "attempt", "", "", "show logs of the given attempt of tasks with retries, starting at 1, or of all of them with 'all' (default: latest)")
 	c.Flags().StringSliceVarP(&opts.Tasks, "only-tasks", "t", []string{}, "show logs for mentioned tasks only")
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "lists number of pipelineruns")
+	c.Flags().BoolVarP(&opts.Timestamps, "timestamps", "", false, "show the timestamp of each log line")
+	c.Flags().DurationVarP(&opts.Since, "since", "", 0, "only show logs newer than a relative duration like 30s, 10m or 2h")
+	c.Flags().StringVarP(&opts.SinceTime, "since-time", "", "", "only show logs after a date in RFC3339 format")
+	c.Flags().Int64VarP(&opts.Tail, "tail", "", 0, "number of most recent lines to show for each step (default: all)")
+	c.Flags().StringVarP(&opts.Output, "output", "o", "", "output format of the logs, json writes one JSON object per line (default: text)")
+	c.Flags().BoolVarP(&opts.Interleave, "interleave", "", false, "merge the timestamped lines of the tasks in chronological order instead of showing the logs task by task")
+	c.Flags().StringVarP(&opts.OutputDir, "output-dir", "", "", "write the logs of each step to <task>/<step>.log under this directory, along with a manifest.json")
+	c.Flags().StringVarP(&opts.Archive, "archive", "", "", "write the logs of each step and a manifest.json to this gzipped tarball")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipelinerun")
 	return c
 }
 
-func Run(opts *options.LogOptions) error {
+func Run(ctx context.Context, opts *options.LogOptions) error {
 	if opts.PipelineRunName == "" {
 		if err := askRunName(opts); err != nil {
 			return err
@@ -92,23 +131,58 @@ func Run(opts *options.LogOptions) error {
 		return err
 	}
 
+	logOpts, err := opts.PodLogOptions()
+	if err != nil {
+		return err
+	}
+
+	jsonOutput, err := opts.JSONOutput()
+	if err != nil {
+		return err
+	}
+
+	attempt, err := opts.AttemptNumber()
+	if err != nil {
+		return err
+	}
+
 	lr := &LogReader{
-		Run:      opts.PipelineRunName,
-		Ns:       opts.Params.Namespace(),
-		Clients:  cs,
-		Streamer: streamer,
-		Stream:   opts.Stream,
-		Follow:   opts.Follow,
-		AllSteps: opts.AllSteps,
-		Tasks:    opts.Tasks,
+		Run:        opts.PipelineRunName,
+		Ns:         opts.Params.Namespace(),
+		Clients:    cs,
+		Streamer:   streamer,
+		Stream:     opts.Stream,
+		Follow:     opts.Follow,
+		AllSteps:   opts.AllSteps,
+		Steps:      opts.Steps,
+		Sidecars:   opts.Sidecars,
+		Attempt:    attempt,
+		Tasks:      opts.Tasks,
+		LogOptions: logOpts,
+		Interleave: opts.Interleave,
+	}
+
+	if opts.OutputDir != "" || opts.Archive != "" {
+		return exportLogs(ctx, opts, lr)
 	}
 
-	logC, errC, err := lr.Read()
+	logC, errC, err := lr.Read(ctx)
 	if err != nil {
+		// interrupted while waiting for the run to start
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
 	}
 
-	NewLogWriter().Write(opts.Stream, logC, errC)
+	if jsonOutput {
+		(&JSONLogWriter{PipelineRun: opts.PipelineRunName}).Write(opts.Stream, logC, errC)
+		return nil
+	}
+
+	lw := NewLogWriter()
+	lw.Interleaved = opts.Interleave
+	lw.Write(opts.Stream, logC, errC)
 
 	return nil
 }
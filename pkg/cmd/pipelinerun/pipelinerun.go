@@ -41,6 +41,8 @@ func Command(p cli.Params) *cobra.Command {
 		logCommand(p),
 		cancelCommand(p),
 		deleteCommand(p),
+		resultsCommand(p),
+		timelineCommand(p),
 	)
 
 	return c
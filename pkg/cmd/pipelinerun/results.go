@@ -0,0 +1,106 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/results"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func resultsCommand(p cli.Params) *cobra.Command {
+	output := ""
+	eg := `Show the results of the resources of the PipelineRun named 'foo' from namespace 'bar':
+
+    tkn pipelinerun results foo -n bar
+
+Read the digest of the image built by the task 'build' of the PipelineRun named 'foo':
+
+    tkn pr results foo -o json | jq -r '.[] | select(.task == "build" and .key == "digest") | .value'
+`
+
+	c := &cobra.Command{
+		Use:          "results",
+		Short:        "Show the results of the resources of a pipelinerun, like the digests of the images it built",
+		Example:      eg,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			if output != "" && output != options.OutputJSON {
+				return fmt.Errorf("output format %q is not supported, use %s", output, options.OutputJSON)
+			}
+
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			return printPipelineRunResults(s, p, args[0], output)
+		},
+	}
+
+	c.Flags().StringVarP(&output, "output", "o", "", "output format of the results, json writes a JSON array (default: text)")
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipelinerun")
+	return c
+}
+
+func printPipelineRunResults(s *cli.Stream, p cli.Params, prName, output string) error {
+	cs, err := p.Clients()
+	if err != nil {
+		return fmt.Errorf("failed to create tekton client")
+	}
+
+	pr, err := cs.Tekton.TektonV1alpha1().PipelineRuns(p.Namespace()).Get(prName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find pipelinerun %q", prName)
+	}
+
+	res := results.PipelineRun(pr)
+
+	if output == options.OutputJSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(s.Out, string(data))
+		return err
+	}
+
+	if len(res) == 0 {
+		fmt.Fprintf(s.Out, "No results found for pipelinerun %s\n", prName)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "TASK NAME\tTASKRUN\tRESOURCE\tKEY\tVALUE")
+	for _, r := range res {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Task, r.TaskRun, r.Resource, r.Key, r.Value)
+	}
+	return w.Flush()
+}
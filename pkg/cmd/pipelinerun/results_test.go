@@ -0,0 +1,130 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineRunResults(t *testing.T) {
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("release", "ns",
+			tb.PipelineRunSpec("release"),
+			tb.PipelineRunStatus(
+				tb.PipelineRunTaskRunsStatus("release-build-a9f3", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "build",
+					Status: &v1alpha1.TaskRunStatus{
+						ResourcesResult: []v1alpha1.PipelineResourceResult{
+							{Key: "digest", Value: "sha256:1234", ResourceRef: v1alpha1.PipelineResourceRef{Name: "app-image"}},
+						},
+					},
+				}),
+				tb.PipelineRunTaskRunsStatus("release-test-b1c2", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "test",
+				}),
+			),
+		),
+		tb.PipelineRun("lint", "ns",
+			tb.PipelineRunSpec("lint"),
+		),
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{PipelineRuns: prs, Namespaces: ns})
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Results",
+			command: []string{"results", "release", "-n", "ns"},
+			want: `TASK NAME   TASKRUN              RESOURCE    KEY      VALUE
+build       release-build-a9f3   app-image   digest   sha256:1234
+`,
+		},
+		{
+			name:    "Results as JSON",
+			command: []string{"results", "release", "-n", "ns", "-o", "json"},
+			want: `[
+  {
+    "task": "build",
+    "taskRun": "release-build-a9f3",
+    "resource": "app-image",
+    "key": "digest",
+    "value": "sha256:1234"
+  }
+]
+`,
+		},
+		{
+			name:    "No results",
+			command: []string{"results", "lint", "-n", "ns"},
+			want:    "No results found for pipelinerun lint\n",
+		},
+		{
+			name:    "No results as JSON",
+			command: []string{"results", "lint", "-n", "ns", "-o", "json"},
+			want:    "[]\n",
+		},
+		{
+			name:      "Unsupported output",
+			command:   []string{"results", "release", "-n", "ns", "-o", "yaml"},
+			wantError: true,
+			want:      `output format "yaml" is not supported, use json`,
+		},
+		{
+			name:      "Not found pipelinerun",
+			command:   []string{"results", "nonexistent", "-n", "ns"},
+			wantError: true,
+			want:      `failed to find pipelinerun "nonexistent"`,
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+			out, err := test.ExecuteCommand(Command(p), tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/formatted"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const minTimelineWidth = 10
+
+func timelineCommand(p cli.Params) *cobra.Command {
+	showSteps := false
+	width := 50
+	eg := `Show when each task of the PipelineRun named 'foo' from namespace 'bar' ran:
+
+    tkn pipelinerun timeline foo -n bar
+
+Show the steps of the tasks as well, on a wider timeline:
+
+    tkn pr timeline foo --steps --width 100 -n bar
+`
+
+	c := &cobra.Command{
+		Use:   "timeline",
+		Short: "Show the taskruns of a pipelinerun on a timeline",
+		Long: `Show the taskruns of a pipelinerun on a timeline.
+
+Each taskrun, and each check of the conditions of its task, is drawn as a bar
+between the times it started and completed, colored after its status. The
+tasks marked with a * are on the critical path of the pipeline: the chain of
+tasks depending on each other, through runAfter and from, which took the
+longest to run.`,
+		Example:      eg,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			if width < minTimelineWidth {
+				return fmt.Errorf("width must be at least %d", minTimelineWidth)
+			}
+
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			return printTimeline(s, p, args[0], showSteps, width)
+		},
+	}
+
+	c.Flags().BoolVarP(&showSteps, "steps", "", false, "show the steps of each taskrun")
+	c.Flags().IntVarP(&width, "width", "", width, "number of characters of the timeline")
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_pipelinerun")
+	return c
+}
+
+// timelineRow is a taskrun, condition check or step drawn on the timeline
+type timelineRow struct {
+	name   string
+	status string
+	start  *metav1.Time
+	end    *metav1.Time
+	color  *color.Color
+}
+
+// timeline draws the rows between start and end, with width characters
+type timeline struct {
+	start time.Time
+	end   time.Time
+	width int
+}
+
+func printTimeline(s *cli.Stream, p cli.Params, prName string, showSteps bool, width int) error {
+	cs, err := p.Clients()
+	if err != nil {
+		return fmt.Errorf("failed to create tekton client")
+	}
+
+	pr, err := cs.Tekton.TektonV1alpha1().PipelineRuns(p.Namespace()).Get(prName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find pipelinerun %q", prName)
+	}
+
+	if pr.Status.StartTime.IsZero() {
+		fmt.Fprintf(s.Out, "PipelineRun %s has not started yet\n", prName)
+		return nil
+	}
+
+	tl := &timeline{
+		start: pr.Status.StartTime.Time,
+		end:   p.Time().Now(),
+		width: width,
+	}
+	if !pr.Status.CompletionTime.IsZero() {
+		tl.end = pr.Status.CompletionTime.Time
+	}
+
+	trl := newTaskrunListFromMap(pr.Status.TaskRuns)
+	sort.SliceStable(trl, func(i, j int) bool {
+		si, sj := trl[i].taskRunStatus().StartTime, trl[j].taskRunStatus().StartTime
+		if si.IsZero() || sj.IsZero() {
+			return !si.IsZero() && sj.IsZero()
+		}
+		if si.Equal(sj) {
+			return trl[i].PipelineTaskName < trl[j].PipelineTaskName
+		}
+		return si.Before(sj)
+	})
+
+	var path []string
+	var pathDuration time.Duration
+	spec, err := pipelineSpec(cs, pr)
+	if err != nil {
+		fmt.Fprintf(s.Err, "Failed to get the pipeline of pipelinerun %s, the critical path is not shown: %s\n", prName, err)
+	} else {
+		path, pathDuration = criticalPath(spec.Tasks, taskDurations(trl, tl.end))
+	}
+
+	critical := map[string]bool{}
+	for _, t := range path {
+		critical[t] = true
+	}
+
+	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "TASK\tSTATUS\tSTART\tDURATION\t%s\n", tl.axis())
+	for _, tr := range trl {
+		marker := " "
+		if critical[tr.PipelineTaskName] {
+			marker = "*"
+		}
+
+		status := tr.taskRunStatus()
+		tl.printRow(w, timelineRow{
+			name:   marker + " " + tr.PipelineTaskName,
+			status: formatted.Condition(status.Conditions),
+			start:  status.StartTime,
+			end:    status.CompletionTime,
+			color:  conditionColor(status.Conditions),
+		})
+
+		for _, cc := range conditionChecks(tr.ConditionChecks) {
+			tl.printRow(w, timelineRow{
+				name:   "    condition " + cc.ConditionName,
+				status: formatted.Condition(cc.Status.Conditions),
+				start:  cc.Status.StartTime,
+				end:    cc.Status.CompletionTime,
+				color:  conditionColor(cc.Status.Conditions),
+			})
+		}
+
+		if !showSteps {
+			continue
+		}
+		for _, step := range status.Steps {
+			tl.printRow(w, stepRow(step))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(path) != 0 {
+		fmt.Fprintf(s.Out, "\nCritical path: %s (%s)\n", strings.Join(path, " -> "), roundDuration(pathDuration))
+	}
+	return nil
+}
+
+// pipelineSpec returns the spec of the pipeline run by pr
+func pipelineSpec(cs *cli.Clients, pr *v1alpha1.PipelineRun) (*v1alpha1.PipelineSpec, error) {
+	if pr.Spec.PipelineSpec != nil {
+		return pr.Spec.PipelineSpec, nil
+	}
+	if pr.Spec.PipelineRef == nil {
+		return nil, fmt.Errorf("pipelinerun %s has no pipeline", pr.Name)
+	}
+
+	pipeline, err := cs.Tekton.TektonV1alpha1().Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline.Spec, nil
+}
+
+// taskDurations returns how long each pipeline task took to run, including
+// the checks of its conditions, the tasks still running are counted until
+// now
+func taskDurations(trl taskrunList, now time.Time) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	for _, tr := range trl {
+		status := tr.taskRunStatus()
+		d := duration(status.StartTime, status.CompletionTime, now)
+
+		var checks time.Duration
+		for _, cc := range tr.ConditionChecks {
+			if cc.Status == nil {
+				continue
+			}
+			if c := duration(cc.Status.StartTime, cc.Status.CompletionTime, now); c > checks {
+				checks = c
+			}
+		}
+
+		if d+checks > durations[tr.PipelineTaskName] {
+			durations[tr.PipelineTaskName] = d + checks
+		}
+	}
+	return durations
+}
+
+// criticalPath returns the chain of tasks depending on each other which took
+// the longest to run, along with its duration
+func criticalPath(tasks []v1alpha1.PipelineTask, durations map[string]time.Duration) ([]string, time.Duration) {
+	deps := map[string][]string{}
+	for _, t := range tasks {
+		deps[t.Name] = t.Deps()
+	}
+
+	finish := map[string]time.Duration{}
+	prev := map[string]string{}
+	visiting := map[string]bool{}
+
+	var visit func(name string) time.Duration
+	visit = func(name string) time.Duration {
+		if f, ok := finish[name]; ok {
+			return f
+		}
+		// pipelines are validated to be acyclic, this only guards against
+		// looping forever on an invalid one
+		if visiting[name] {
+			return 0
+		}
+		visiting[name] = true
+
+		var longest time.Duration
+		for _, d := range deps[name] {
+			if _, ok := deps[d]; !ok {
+				continue
+			}
+			if f := visit(d); f > longest || prev[name] == "" {
+				longest = f
+				prev[name] = d
+			}
+		}
+
+		finish[name] = longest + durations[name]
+		return finish[name]
+	}
+
+	last := ""
+	for _, t := range tasks {
+		if f := visit(t.Name); last == "" || f > finish[last] {
+			last = t.Name
+		}
+	}
+
+	if last == "" || finish[last] == 0 {
+		return nil, 0
+	}
+
+	path := []string{}
+	onPath := map[string]bool{}
+	for t := last; t != "" && !onPath[t]; t = prev[t] {
+		onPath[t] = true
+		path = append([]string{t}, path...)
+	}
+	return path, finish[last]
+}
+
+func conditionChecks(checks map[string]*v1alpha1.PipelineRunConditionCheckStatus) []*v1alpha1.PipelineRunConditionCheckStatus {
+	names := []string{}
+	for name, cc := range checks {
+		if cc.Status != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ccs := []*v1alpha1.PipelineRunConditionCheckStatus{}
+	for _, name := range names {
+		ccs = append(ccs, checks[name])
+	}
+	return ccs
+}
+
+func stepRow(step v1alpha1.StepState) timelineRow {
+	row := timelineRow{
+		name:   "    step " + step.Name,
+		status: validate.StepReasonExists(step),
+	}
+
+	switch {
+	case step.Running != nil:
+		row.start = &step.Running.StartedAt
+		row.color = color.New(color.FgYellow)
+	case step.Terminated != nil:
+		row.start = &step.Terminated.StartedAt
+		row.end = &step.Terminated.FinishedAt
+		row.color = color.New(color.FgGreen)
+		if step.Terminated.ExitCode != 0 {
+			row.color = color.New(color.FgRed)
+		}
+	}
+	return row
+}
+
+func (t tkr) taskRunStatus() *v1alpha1.TaskRunStatus {
+	if t.Status == nil {
+		return &v1alpha1.TaskRunStatus{}
+	}
+	return t.Status
+}
+
+func conditionColor(c duckv1beta1.Conditions) *color.Color {
+	if len(c) == 0 || c[0].Type != apis.ConditionSucceeded && c[0].Type != "" {
+		return color.New(color.Reset)
+	}
+
+	switch c[0].Status {
+	case corev1.ConditionTrue:
+		return color.New(color.FgGreen)
+	case corev1.ConditionFalse:
+		return color.New(color.FgRed)
+	}
+	return color.New(color.FgYellow)
+}
+
+// duration returns how long it took from start to end, or until now when it
+// has not ended yet
+func duration(start, end *metav1.Time, now time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	if end.IsZero() {
+		return now.Sub(start.Time)
+	}
+	return end.Sub(start.Time)
+}
+
+func roundDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// axis is the header of the timeline, with the times of its start and end
+func (t *timeline) axis() string {
+	end := roundDuration(t.end.Sub(t.start))
+	pad := t.width - len("0s") - len(end)
+	if pad < 1 {
+		pad = 1
+	}
+	return "|0s" + strings.Repeat(" ", pad) + end + "|"
+}
+
+func (t *timeline) printRow(w io.Writer, r timelineRow) {
+	start, d := "---", "---"
+	if !r.start.IsZero() {
+		start = "+" + roundDuration(r.start.Sub(t.start))
+		d = roundDuration(duration(r.start, r.end, t.end))
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.name, r.status, start, d, t.bar(r))
+}
+
+// bar draws the row as a bar between the times it started and ended
+func (t *timeline) bar(r timelineRow) string {
+	if r.start.IsZero() {
+		return "|" + strings.Repeat(" ", t.width) + "|"
+	}
+
+	end := t.end
+	if !r.end.IsZero() {
+		end = r.end.Time
+	}
+
+	total := float64(t.end.Sub(t.start))
+	if total <= 0 {
+		total = 1
+	}
+	from := int(float64(r.start.Sub(t.start)) / total * float64(t.width))
+	to := int(math.Ceil(float64(end.Sub(t.start)) / total * float64(t.width)))
+
+	if from < 0 {
+		from = 0
+	}
+	if from > t.width-1 {
+		from = t.width - 1
+	}
+	if to <= from {
+		to = from + 1
+	}
+	if to > t.width {
+		to = t.width
+	}
+
+	clr := r.color
+	if clr == nil {
+		clr = color.New(color.Reset)
+	}
+	return "|" + strings.Repeat(" ", from) + clr.Sprint(strings.Repeat("█", to-from)) + strings.Repeat(" ", t.width-to) + "|"
+}
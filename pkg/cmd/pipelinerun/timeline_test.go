@@ -0,0 +1,266 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func timelineTaskRun(task string, start, end time.Time, status corev1.ConditionStatus, reason string) *v1alpha1.PipelineRunTaskRunStatus {
+	tr := &v1alpha1.PipelineRunTaskRunStatus{
+		PipelineTaskName: task,
+		Status: &v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{
+					{Type: apis.ConditionSucceeded, Status: status, Reason: reason},
+				},
+			},
+			StartTime: &metav1.Time{Time: start},
+		},
+	}
+	if !end.IsZero() {
+		tr.Status.CompletionTime = &metav1.Time{Time: end}
+	}
+	return tr
+}
+
+func TestPipelineRunTimeline(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	start := clock.Now()
+	clock.Advance(6 * time.Minute)
+
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("release", "ns",
+			tb.PipelineSpec(
+				tb.PipelineTask("build", "build"),
+				tb.PipelineTask("lint", "lint"),
+				tb.PipelineTask("test", "test",
+					tb.RunAfter("build"),
+					tb.PipelineTaskCondition("has-tests"),
+				),
+				tb.PipelineTask("deploy", "deploy",
+					tb.RunAfter("lint"),
+					tb.PipelineTaskInputResource("image", "app-image", tb.From("test")),
+				),
+			),
+		),
+	}
+
+	testTr := timelineTaskRun("test", start.Add(3*time.Minute), start.Add(5*time.Minute), corev1.ConditionTrue, "Succeeded")
+	testTr.ConditionChecks = map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+		"release-test-has-tests": {
+			ConditionName: "has-tests",
+			Status: &v1alpha1.ConditionCheckStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{
+						{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+					},
+				},
+				StartTime:      &metav1.Time{Time: start.Add(2 * time.Minute)},
+				CompletionTime: &metav1.Time{Time: start.Add(3 * time.Minute)},
+			},
+		},
+	}
+
+	build := timelineTaskRun("build", start, start.Add(2*time.Minute), corev1.ConditionTrue, "Succeeded")
+	build.Status.Steps = []v1alpha1.StepState{
+		{
+			Name: "compile",
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason:     "Completed",
+					StartedAt:  metav1.Time{Time: start},
+					FinishedAt: metav1.Time{Time: start.Add(90 * time.Second)},
+				},
+			},
+		},
+		{
+			Name: "package",
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason:     "Completed",
+					StartedAt:  metav1.Time{Time: start.Add(90 * time.Second)},
+					FinishedAt: metav1.Time{Time: start.Add(2 * time.Minute)},
+				},
+			},
+		},
+	}
+
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("release-1", "ns",
+			tb.PipelineRunSpec("release"),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStartTime(start),
+				tb.PipelineRunTaskRunsStatus("release-1-build", build),
+				tb.PipelineRunTaskRunsStatus("release-1-lint",
+					timelineTaskRun("lint", start, start.Add(time.Minute), corev1.ConditionTrue, "Succeeded")),
+				tb.PipelineRunTaskRunsStatus("release-1-test", testTr),
+				tb.PipelineRunTaskRunsStatus("release-1-deploy",
+					timelineTaskRun("deploy", start.Add(5*time.Minute), time.Time{}, corev1.ConditionUnknown, "Running")),
+			),
+		),
+		tb.PipelineRun("release-2", "ns",
+			tb.PipelineRunSpec("release"),
+		),
+		tb.PipelineRun("missing-pipeline", "ns",
+			tb.PipelineRunSpec("missing"),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStartTime(start),
+				tb.PipelineRunCompletionTime(start.Add(time.Minute)),
+				tb.PipelineRunTaskRunsStatus("missing-pipeline-build",
+					timelineTaskRun("build", start, start.Add(time.Minute), corev1.ConditionFalse, "Failed")),
+			),
+		),
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pipelines: ps, PipelineRuns: prs, Namespaces: ns})
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Timeline",
+			command: []string{"timeline", "release-1", "-n", "ns", "--width", "12"},
+			want: `TASK                      STATUS      START   DURATION   |0s      6m0s|
+* build                   Succeeded   +0s     2m0s       |████        |
+  lint                    Succeeded   +0s     1m0s       |██          |
+* test                    Succeeded   +3m0s   2m0s       |      ████  |
+    condition has-tests   Succeeded   +2m0s   1m0s       |    ██      |
+* deploy                  Running     +5m0s   1m0s       |          ██|
+
+Critical path: build -> test -> deploy (6m0s)
+`,
+		},
+		{
+			name:    "Timeline with steps",
+			command: []string{"timeline", "release-1", "-n", "ns", "--width", "12", "--steps"},
+			want: `TASK                      STATUS      START    DURATION   |0s      6m0s|
+* build                   Succeeded   +0s      2m0s       |████        |
+    step compile          Completed   +0s      1m30s      |███         |
+    step package          Completed   +1m30s   30s        |   █        |
+  lint                    Succeeded   +0s      1m0s       |██          |
+* test                    Succeeded   +3m0s    2m0s       |      ████  |
+    condition has-tests   Succeeded   +2m0s    1m0s       |    ██      |
+* deploy                  Running     +5m0s    1m0s       |          ██|
+
+Critical path: build -> test -> deploy (6m0s)
+`,
+		},
+		{
+			name:    "Not started",
+			command: []string{"timeline", "release-2", "-n", "ns"},
+			want:    "PipelineRun release-2 has not started yet\n",
+		},
+		{
+			name:    "Pipeline not found",
+			command: []string{"timeline", "missing-pipeline", "-n", "ns", "--width", "12"},
+			want: `Failed to get the pipeline of pipelinerun missing-pipeline, the critical path is not shown: pipelines.tekton.dev "missing" not found
+TASK      STATUS   START   DURATION   |0s      1m0s|
+  build   Failed   +0s     1m0s       |████████████|
+`,
+		},
+		{
+			name:      "Width too small",
+			command:   []string{"timeline", "release-1", "-n", "ns", "--width", "5"},
+			wantError: true,
+			want:      "width must be at least 10",
+		},
+		{
+			name:      "Not found pipelinerun",
+			command:   []string{"timeline", "nonexistent", "-n", "ns"},
+			wantError: true,
+			want:      `failed to find pipelinerun "nonexistent"`,
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube, Clock: clock}
+			pipelinerun := Command(p)
+
+			out, err := test.ExecuteCommand(pipelinerun, tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	tasks := []v1alpha1.PipelineTask{
+		{Name: "build"},
+		{Name: "lint"},
+		{Name: "test", RunAfter: []string{"build"}},
+		{Name: "deploy", RunAfter: []string{"lint", "test"}},
+	}
+
+	path, d := criticalPath(tasks, map[string]time.Duration{
+		"build":  2 * time.Minute,
+		"lint":   time.Minute,
+		"test":   3 * time.Minute,
+		"deploy": time.Minute,
+	})
+	test.AssertOutput(t, []string{"build", "test", "deploy"}, path)
+	test.AssertOutput(t, 6*time.Minute, d)
+
+	path, d = criticalPath(tasks, map[string]time.Duration{
+		"build":  time.Minute,
+		"lint":   10 * time.Minute,
+		"test":   time.Minute,
+		"deploy": time.Minute,
+	})
+	test.AssertOutput(t, []string{"lint", "deploy"}, path)
+	test.AssertOutput(t, 11*time.Minute, d)
+
+	path, _ = criticalPath(tasks, map[string]time.Duration{})
+	test.AssertOutput(t, 0, len(path))
+
+	// a cycle does not make it loop forever
+	path, _ = criticalPath([]v1alpha1.PipelineTask{
+		{Name: "a", RunAfter: []string{"b"}},
+		{Name: "b", RunAfter: []string{"a"}},
+	}, map[string]time.Duration{"a": time.Minute, "b": time.Minute})
+	test.AssertOutput(t, []string{"b", "a"}, path)
+}
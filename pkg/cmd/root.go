@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/cli/pkg/cmd/pipeline"
 	"github.com/tektoncd/cli/pkg/cmd/pipelineresource"
 	"github.com/tektoncd/cli/pkg/cmd/pipelinerun"
+	"github.com/tektoncd/cli/pkg/cmd/run"
 	"github.com/tektoncd/cli/pkg/cmd/task"
 	"github.com/tektoncd/cli/pkg/cmd/taskrun"
 	"github.com/tektoncd/cli/pkg/cmd/triggerbinding"
@@ -85,6 +86,7 @@ func Root(p cli.Params) *cobra.Command {
 		pipeline.Command(p),
 		pipelineresource.Command(p),
 		pipelinerun.Command(p),
+		run.Command(p),
 		task.Command(p),
 		taskrun.Command(p),
 		triggerbinding.Command(p),
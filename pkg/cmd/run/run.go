@@ -0,0 +1,432 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/cmd/pipeline"
+	"github.com/tektoncd/cli/pkg/cmd/pipelinerun"
+	"github.com/tektoncd/cli/pkg/flags"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/params"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	defaultPath = ".tekton"
+	runFile     = "run.yaml"
+)
+
+type runOptions struct {
+	Path               string
+	ServiceAccountName string
+	Params             []string
+	Labels             []string
+	ShowLog            bool
+}
+
+// Config is the content of the run.yaml file which designates the
+// pipeline to start and the values it is started with
+type Config struct {
+	Pipeline       string                            `json:"pipeline"`
+	ServiceAccount string                            `json:"serviceAccount,omitempty"`
+	Params         map[string]v1alpha1.ArrayOrString `json:"params,omitempty"`
+	Resources      map[string]string                 `json:"resources,omitempty"`
+}
+
+type definitions struct {
+	tasks      []*v1alpha1.Task
+	pipelines  []*v1alpha1.Pipeline
+	conditions []*v1alpha1.Condition
+	resources  []*v1alpha1.PipelineResource
+}
+
+func Command(p cli.Params) *cobra.Command {
+	opts := &runOptions{}
+	eg := `Apply the definitions found in .tekton/ of the current directory and
+start the pipeline designated in .tekton/run.yaml in namespace 'bar':
+
+    tkn run -n bar
+
+Do the same for the repository checked out in ../api, using definitions
+stored in ci/tekton:
+
+    tkn run ../api --path ci/tekton
+
+The run.yaml file names the pipeline to start along with its params,
+resources and service account:
+
+    pipeline: build-and-deploy
+    serviceAccount: builder
+    params:
+      image: gcr.io/foo/api
+      flags: [--verbose, --race]
+    resources:
+      source: api-git
+`
+
+	c := &cobra.Command{
+		Use:          "run [DIR]",
+		Short:        "Apply and start the pipeline of a repository",
+		Example:      eg,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return flags.InitParams(p, cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return opts.run(ctx, s, p, dir)
+		},
+	}
+
+	flags.AddTektonOptions(c)
+	c.Flags().StringVar(&opts.Path, "path", defaultPath, "path of the directory holding the tekton definitions, relative to DIR")
+	c.Flags().StringVarP(&opts.ServiceAccountName, "serviceaccount", "s", "", "pass the serviceaccount name, overrides the one of "+runFile)
+	flags.AddShellCompletion(c.Flags().Lookup("serviceaccount"), "__kubectl_get_serviceaccount")
+	c.Flags().StringArrayVarP(&opts.Params, "param", "p", []string{}, "pass the param as key=value or key=value1,value2, overrides the one of "+runFile)
+	c.Flags().StringSliceVarP(&opts.Labels, "labels", "l", []string{}, "pass labels as label=value.")
+	c.Flags().BoolVarP(&opts.ShowLog, "showlog", "", true, "show logs right after starting the pipeline")
+
+	return c
+}
+
+func (opts *runOptions) run(ctx context.Context, s *cli.Stream, p cli.Params, dir string) error {
+	path := filepath.Join(dir, opts.Path)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return fmt.Errorf("no tekton definitions found: %s is not a directory", path)
+	}
+
+	defs, err := discover(path)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig(path, defs)
+	if err != nil {
+		return err
+	}
+
+	if err := apply(s, p, defs); err != nil {
+		return err
+	}
+
+	return opts.startPipeline(ctx, s, p, config)
+}
+
+// discover parses all the yaml files found under path, skipping run.yaml,
+// and collects the tekton definitions they contain
+func discover(path string) (*definitions, error) {
+	defs := &definitions{}
+
+	err := filepath.Walk(path, func(f string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() == runFile {
+			return nil
+		}
+		if ext := filepath.Ext(f); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		return defs.load(f)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return defs, nil
+}
+
+func (d *definitions) load(f string) error {
+	content, err := ioutil.ReadFile(f)
+	if err != nil {
+		return err
+	}
+
+	r := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %s", f, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		if err := d.add(doc); err != nil {
+			return fmt.Errorf("failed to load %s: %s", f, err)
+		}
+	}
+}
+
+func (d *definitions) add(doc []byte) error {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		return err
+	}
+
+	switch meta.Kind {
+	case "Task":
+		var t v1alpha1.Task
+		if err := yaml.Unmarshal(doc, &t); err != nil {
+			return err
+		}
+		d.tasks = append(d.tasks, &t)
+	case "Pipeline":
+		var pl v1alpha1.Pipeline
+		if err := yaml.Unmarshal(doc, &pl); err != nil {
+			return err
+		}
+		d.pipelines = append(d.pipelines, &pl)
+	case "Condition":
+		var c v1alpha1.Condition
+		if err := yaml.Unmarshal(doc, &c); err != nil {
+			return err
+		}
+		d.conditions = append(d.conditions, &c)
+	case "PipelineResource":
+		var r v1alpha1.PipelineResource
+		if err := yaml.Unmarshal(doc, &r); err != nil {
+			return err
+		}
+		d.resources = append(d.resources, &r)
+	case "":
+		return fmt.Errorf("document has no kind")
+	default:
+		return fmt.Errorf("unsupported kind %s", meta.Kind)
+	}
+	return nil
+}
+
+// loadConfig reads run.yaml; without it the only pipeline defined is started
+func loadConfig(path string, defs *definitions) (*Config, error) {
+	config := &Config{}
+
+	content, err := ioutil.ReadFile(filepath.Join(path, runFile))
+	switch {
+	case os.IsNotExist(err):
+		if len(defs.pipelines) != 1 {
+			return nil, fmt.Errorf("%s is required to designate the pipeline to run when %d pipelines are defined", filepath.Join(path, runFile), len(defs.pipelines))
+		}
+		config.Pipeline = defs.pipelines[0].Name
+		return config, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", runFile, err)
+	}
+
+	if config.Pipeline == "" {
+		if len(defs.pipelines) != 1 {
+			return nil, fmt.Errorf("%s must name the pipeline to run", runFile)
+		}
+		config.Pipeline = defs.pipelines[0].Name
+	}
+
+	return config, nil
+}
+
+// apply creates the definitions in the namespace, updating the ones
+// which already exist
+func apply(s *cli.Stream, p cli.Params, defs *definitions) error {
+	cs, err := p.Clients()
+	if err != nil {
+		return err
+	}
+
+	ns := p.Namespace()
+	tekton := cs.Tekton.TektonV1alpha1()
+
+	for _, r := range defs.resources {
+		r.Namespace = ns
+		err := applyObject(s, "PipelineResource", r,
+			func() (metav1.Object, error) { return tekton.PipelineResources(ns).Get(r.Name, metav1.GetOptions{}) },
+			func() (err error) { _, err = tekton.PipelineResources(ns).Create(r); return },
+			func() (err error) { _, err = tekton.PipelineResources(ns).Update(r); return },
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range defs.conditions {
+		c.Namespace = ns
+		err := applyObject(s, "Condition", c,
+			func() (metav1.Object, error) { return tekton.Conditions(ns).Get(c.Name, metav1.GetOptions{}) },
+			func() (err error) { _, err = tekton.Conditions(ns).Create(c); return },
+			func() (err error) { _, err = tekton.Conditions(ns).Update(c); return },
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range defs.tasks {
+		t.Namespace = ns
+		err := applyObject(s, "Task", t,
+			func() (metav1.Object, error) { return tekton.Tasks(ns).Get(t.Name, metav1.GetOptions{}) },
+			func() (err error) { _, err = tekton.Tasks(ns).Create(t); return },
+			func() (err error) { _, err = tekton.Tasks(ns).Update(t); return },
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pl := range defs.pipelines {
+		pl.Namespace = ns
+		err := applyObject(s, "Pipeline", pl,
+			func() (metav1.Object, error) { return tekton.Pipelines(ns).Get(pl.Name, metav1.GetOptions{}) },
+			func() (err error) { _, err = tekton.Pipelines(ns).Create(pl); return },
+			func() (err error) { _, err = tekton.Pipelines(ns).Update(pl); return },
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyObject creates obj with create when get doesn't find it, otherwise
+// updates it with update from the version of the existing object
+func applyObject(s *cli.Stream, kind string, obj metav1.Object, get func() (metav1.Object, error), create, update func() error) error {
+	action := "created"
+	old, err := get()
+	if err == nil {
+		obj.SetResourceVersion(old.GetResourceVersion())
+		err = update()
+		action = "updated"
+	} else if errors.IsNotFound(err) {
+		err = create()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %s", strings.ToLower(kind), obj.GetName(), err)
+	}
+
+	fmt.Fprintf(s.Out, "%s %s: %s\n", kind, action, obj.GetName())
+	return nil
+}
+
+func (opts *runOptions) startPipeline(ctx context.Context, s *cli.Stream, p cli.Params, config *Config) error {
+	cs, err := p.Clients()
+	if err != nil {
+		return err
+	}
+
+	ns := p.Namespace()
+	pl, err := cs.Tekton.TektonV1alpha1().Pipelines(ns).Get(config.Pipeline, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("pipeline name %s does not exist in namespace %s", config.Pipeline, ns)
+	}
+	params.FilterParamsByType(pl.Spec.Params)
+
+	spec := v1alpha1.PipelineRunSpec{ServiceAccountName: config.ServiceAccount}
+
+	resources := make([]string, 0, len(config.Resources))
+	for name := range config.Resources {
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+	for _, name := range resources {
+		spec.Resources = append(spec.Resources, v1alpha1.PipelineResourceBinding{
+			Name:        name,
+			ResourceRef: &v1alpha1.PipelineResourceRef{Name: config.Resources[name]},
+		})
+	}
+
+	names := make([]string, 0, len(config.Params))
+	for name := range config.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec.Params = append(spec.Params, v1alpha1.Param{Name: name, Value: config.Params[name]})
+	}
+
+	pr, err := pipeline.BuildPipelineRun(p, config.Pipeline, spec, pipeline.StartOptions{
+		Params:             opts.Params,
+		ServiceAccountName: opts.ServiceAccountName,
+		Labels:             opts.Labels,
+	})
+	if err != nil {
+		return err
+	}
+	invoker.Current(p).Annotate(&pr.ObjectMeta)
+
+	prCreated, err := cs.Tekton.TektonV1alpha1().PipelineRuns(ns).Create(pr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.Out, "Pipelinerun started: %s\n", prCreated.Name)
+	if !opts.ShowLog {
+		fmt.Fprintf(s.Out, "\nIn order to track the pipelinerun progress run:\ntkn pipelinerun logs %s -f -n %s\n", prCreated.Name, prCreated.Namespace)
+		return nil
+	}
+
+	fmt.Fprintf(s.Out, "Showing logs...\n")
+	runLogOpts := &options.LogOptions{
+		PipelineName:    config.Pipeline,
+		PipelineRunName: prCreated.Name,
+		Stream:          s,
+		Follow:          true,
+		Params:          p,
+		AllSteps:        false,
+	}
+	return pipelinerun.Run(ctx, runLogOpts)
+}
@@ -0,0 +1,153 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stest "k8s.io/client-go/testing"
+)
+
+func TestRun_invalid_namespace(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{})
+	c := Command(&test.Params{Tekton: cs.Pipeline, Kube: cs.Kube})
+
+	_, err := test.ExecuteCommand(c, "./testdata/repo", "-n", "invalid")
+	if err == nil {
+		t.Fatal("Expected an error for invalid namespace")
+	}
+	test.AssertOutput(t, "namespaces \"invalid\" not found", err.Error())
+}
+
+func TestRun(t *testing.T) {
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+	tasks := []*v1alpha1.Task{
+		tb.Task("unit-test", "ns", tb.TaskSpec(tb.Step("old", "busybox"))),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Namespaces: ns, Tasks: tasks})
+	c := Command(&test.Params{Tekton: cs.Pipeline, Kube: cs.Kube})
+
+	got, err := test.ExecuteCommand(c, "./testdata/repo", "-p=image=gcr.io/foo/api:dev", "--showlog=false", "-n", "ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "PipelineResource created: api-git\n" +
+		"Condition created: is-main\n" +
+		"Task updated: unit-test\n" +
+		"Pipeline created: ci\n" +
+		"Pipelinerun started: \n\nIn order to track the pipelinerun progress run:\ntkn pipelinerun logs  -f -n ns\n"
+	test.AssertOutput(t, expected, got)
+
+	task, err := cs.Pipeline.TektonV1alpha1().Tasks("ns").Get("unit-test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "test", task.Spec.Steps[0].Name)
+
+	prs, err := cs.Pipeline.TektonV1alpha1().PipelineRuns("ns").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pr := prs.Items[0]
+	test.AssertOutput(t, "ci-run-", pr.GenerateName)
+	test.AssertOutput(t, "ci", pr.Spec.PipelineRef.Name)
+	test.AssertOutput(t, "builder", pr.Spec.ServiceAccountName)
+	test.AssertOutput(t, []v1alpha1.PipelineResourceBinding{
+		{Name: "source", ResourceRef: &v1alpha1.PipelineResourceRef{Name: "api-git"}},
+	}, pr.Spec.Resources)
+	test.AssertOutput(t, []v1alpha1.Param{
+		{Name: "flags", Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeArray, ArrayVal: []string{"-v", "-race"}}},
+		{Name: "image", Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "gcr.io/foo/api:dev"}},
+	}, pr.Spec.Params)
+}
+
+func TestRun_errors(t *testing.T) {
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	testParams := []struct {
+		name    string
+		command []string
+		want    string
+	}{
+		{
+			name:    "No tekton directory",
+			command: []string{"./testdata", "-n", "ns"},
+			want:    "no tekton definitions found: testdata/.tekton is not a directory",
+		},
+		{
+			name:    "No run file with more than one pipeline",
+			command: []string{"./testdata/norunfile", "-n", "ns"},
+			want:    "testdata/norunfile/.tekton/run.yaml is required to designate the pipeline to run when 2 pipelines are defined",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			cs, _ := test.SeedTestData(t, pipelinetest.Data{Namespaces: ns})
+			c := Command(&test.Params{Tekton: cs.Pipeline, Kube: cs.Kube})
+
+			_, err := test.ExecuteCommand(c, tp.command...)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			test.AssertOutput(t, tp.want, err.Error())
+		})
+	}
+}
+
+func TestRun_apply_error(t *testing.T) {
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Namespaces: ns})
+	cs.Pipeline.PrependReactor("create", "pipelines", func(action k8stest.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("quota exceeded")
+	})
+	c := Command(&test.Params{Tekton: cs.Pipeline, Kube: cs.Kube})
+
+	_, err := test.ExecuteCommand(c, "./testdata/repo", "--showlog=false", "-n", "ns")
+	if err == nil {
+		t.Fatal("Expected an error applying the pipeline")
+	}
+	test.AssertOutput(t, "failed to apply pipeline ci: quota exceeded", err.Error())
+}
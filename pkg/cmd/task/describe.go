@@ -17,6 +17,8 @@ package task
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
@@ -24,10 +26,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/cli/pkg/printer"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const describeTemplate = `Name:	{{ .Task.Name }}
@@ -84,12 +89,26 @@ NAME	TYPE	DEFAULT VALUE
 {{- end }}
 
 Steps
-{{- if eq (len .Task.Spec.Steps) 0 }}
+{{- if eq (len .Steps) 0 }}
 No steps
 {{- else }}
-NAME
-{{- range $step := .Task.Spec.Steps }}
-{{ $step.Name }}
+{{- range $step := .Steps }}
+{{ template "container" $step }}
+{{- end }}
+{{- end }}
+{{- if ne (len .Task.Spec.Volumes) 0 }}
+
+Volumes
+NAME	TYPE	SOURCE
+{{- range $v := .Volumes }}
+{{ $v.Name }}	{{ $v.Type }}	{{ $v.Source }}
+{{- end }}
+{{- end }}
+{{- if ne (len .Sidecars) 0 }}
+
+Sidecars
+{{- range $sidecar := .Sidecars }}
+{{ template "container" $sidecar }}
 {{- end }}
 {{- end }}
 
@@ -102,10 +121,69 @@ NAME	STARTED	DURATION	STATUS
 {{- $tr.Name }}	{{ formatAge $tr.Status.StartTime $.Time}}	{{ formatDuration $tr.Status.StartTime $tr.Status.CompletionTime }}	{{ formatCondition $tr.Status.Conditions }}
 {{ end }}
 {{- end }}
+{{- define "container" }}{{ .Name }}
+  Image:	{{ .Image }}
+{{- if .Command }}
+  Command:	{{ .Command }}
+{{- end }}
+{{- if .Args }}
+  Args:	{{ .Args }}
+{{- end }}
+{{- if .WorkingDir }}
+  Working Dir:	{{ .WorkingDir }}
+{{- end }}
+{{- if .Env }}
+  Env:	{{ .Env }}
+{{- end }}
+{{- if .Requests }}
+  Requests:	{{ .Requests }}
+{{- end }}
+{{- if .Limits }}
+  Limits:	{{ .Limits }}
+{{- end }}
+{{- if .VolumeMounts }}
+  Volume Mounts:	{{ .VolumeMounts }}
+{{- end }}
+{{- if .Script }}
+  Script:
+{{- range $line := .Script }}
+    {{ $line }}
+{{- end }}
+{{- end }}
+{{- end }}
 `
 
+// maxCommandLength and maxScriptLines are how much of the commands and the
+// scripts of the steps are shown without --full
+const (
+	maxCommandLength = 80
+	maxScriptLines   = 5
+)
+
+// containerDetail is what a step or a sidecar of the task runs
+type containerDetail struct {
+	Name         string
+	Image        string
+	Command      string
+	Args         string
+	WorkingDir   string
+	Env          string
+	Requests     string
+	Limits       string
+	VolumeMounts string
+	Script       []string
+}
+
+// volumeDetail is a volume of the task, along with the object it comes from
+type volumeDetail struct {
+	Name   string
+	Type   string
+	Source string
+}
+
 func describeCommand(p cli.Params) *cobra.Command {
-	f := cliopts.NewPrintFlags("describe")
+	opts := options.NewDescribeOptions()
+	full := false
 	eg := `Describe a Task of name 'foo' in namespace 'bar':
 
     tkn task describe foo -n bar
@@ -113,6 +191,10 @@ func describeCommand(p cli.Params) *cobra.Command {
 or
 
    tkn t desc foo -n bar
+
+Show the whole scripts, commands and args of the steps of the Task:
+
+    tkn task describe foo -n bar --full
 `
 
 	c := &cobra.Command{
@@ -135,16 +217,17 @@ or
 				return err
 			}
 
-			return printTaskDescription(s, p, args[0])
+			return printTaskDescription(s, p, args[0], opts, full)
 		},
 	}
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_task")
-	f.AddFlags(c)
+	opts.AddFlags(c)
+	c.Flags().BoolVarP(&full, "full", "", false, "show the whole scripts, commands and args of the steps and sidecars")
 	return c
 }
 
-func printTaskDescription(s *cli.Stream, p cli.Params, tname string) error {
+func printTaskDescription(s *cli.Stream, p cli.Params, tname string, opts *options.DescribeOptions, full bool) error {
 	cs, err := p.Clients()
 	if err != nil {
 		return fmt.Errorf("failed to create tekton client")
@@ -156,6 +239,17 @@ func printTaskDescription(s *cli.Stream, p cli.Params, tname string) error {
 		return err
 	}
 
+	if opts.Output() != "" {
+		// NOTE: this is required for -o json|yaml to work properly since
+		// tektoncd go client fails to set these; probably a bug
+		task.GetObjectKind().SetGroupVersionKind(
+			schema.GroupVersionKind{
+				Version: "tekton.dev/v1alpha1",
+				Kind:    "Task",
+			})
+		return printer.PrintObject(s.Out, task, opts.PrintFlags)
+	}
+
 	if task.Spec.Inputs != nil {
 		task.Spec.Inputs.Resources = sortResourcesByTypeAndName(task.Spec.Inputs.Resources)
 	}
@@ -164,23 +258,42 @@ func printTaskDescription(s *cli.Stream, p cli.Params, tname string) error {
 		task.Spec.Outputs.Resources = sortResourcesByTypeAndName(task.Spec.Outputs.Resources)
 	}
 
-	opts := metav1.ListOptions{
+	lOpts := metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("tekton.dev/task=%s", tname),
 	}
-	taskRuns, err := cs.Tekton.TektonV1alpha1().TaskRuns(p.Namespace()).List(opts)
+	taskRuns, err := cs.Tekton.TektonV1alpha1().TaskRuns(p.Namespace()).List(lOpts)
 	if err != nil {
 		fmt.Fprintf(s.Err, "failed to get taskruns for task %s \n", tname)
 		return err
 	}
 
+	steps := []containerDetail{}
+	for i, step := range task.Spec.Steps {
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("unnamed-%d", i)
+		}
+		steps = append(steps, containerDetails(step.Container, step.Script, full))
+	}
+
+	sidecars := []containerDetail{}
+	for _, sidecar := range task.Spec.Sidecars {
+		sidecars = append(sidecars, containerDetails(sidecar, "", full))
+	}
+
 	var data = struct {
 		Task     *v1alpha1.Task
 		TaskRuns *v1alpha1.TaskRunList
 		Time     clockwork.Clock
+		Steps    []containerDetail
+		Sidecars []containerDetail
+		Volumes  []volumeDetail
 	}{
 		Task:     task,
 		TaskRuns: taskRuns,
 		Time:     p.Time(),
+		Steps:    steps,
+		Sidecars: sidecars,
+		Volumes:  volumeDetails(task.Spec.Volumes),
 	}
 
 	funcMap := template.FuncMap{
@@ -189,14 +302,18 @@ func printTaskDescription(s *cli.Stream, p cli.Params, tname string) error {
 		"formatCondition": formatted.Condition,
 	}
 
+	t, err := opts.Template("Describe Task", describeTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("Describe Task").Funcs(funcMap).Parse(describeTemplate))
 	err = t.Execute(w, data)
 	if err != nil {
 		fmt.Fprintf(s.Err, "Failed to execute template \n")
 		return err
 	}
-	return nil
+	return w.Flush()
 }
 
 // this will sort the Task Resource by Type and then by Name
@@ -215,3 +332,104 @@ func sortResourcesByTypeAndName(tres []v1alpha1.TaskResource) []v1alpha1.TaskRes
 
 	return tres
 }
+
+func containerDetails(c corev1.Container, script string, full bool) containerDetail {
+	env := []string{}
+	for _, e := range c.Env {
+		env = append(env, e.Name)
+	}
+
+	mounts := []string{}
+	for _, m := range c.VolumeMounts {
+		mounts = append(mounts, m.Name+":"+m.MountPath)
+	}
+
+	d := containerDetail{
+		Name:         c.Name,
+		Image:        c.Image,
+		Command:      truncate(joinArgs(c.Command), full),
+		Args:         truncate(joinArgs(c.Args), full),
+		WorkingDir:   c.WorkingDir,
+		Env:          strings.Join(env, ", "),
+		Requests:     resourceList(c.Resources.Requests),
+		Limits:       resourceList(c.Resources.Limits),
+		VolumeMounts: strings.Join(mounts, ", "),
+	}
+
+	if script == "" {
+		return d
+	}
+
+	// the tabs would be taken as the columns of the output
+	lines := strings.Split(strings.TrimRight(strings.Replace(script, "\t", "    ", -1), "\n"), "\n")
+	if !full && len(lines) > maxScriptLines {
+		more := len(lines) - maxScriptLines
+		lines = append(lines[:maxScriptLines], fmt.Sprintf("... %d more lines, use --full to see them all", more))
+	}
+	d.Script = lines
+	return d
+}
+
+// joinArgs joins the args, quoting the ones with spaces so that they can be
+// told apart
+func joinArgs(args []string) string {
+	quoted := []string{}
+	for _, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n") {
+			a = strconv.Quote(a)
+		}
+		quoted = append(quoted, a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// truncate cuts s to maxCommandLength characters unless full is set
+func truncate(s string, full bool) string {
+	r := []rune(s)
+	if full || len(r) <= maxCommandLength {
+		return s
+	}
+	return string(r[:maxCommandLength]) + "..."
+}
+
+func resourceList(rl corev1.ResourceList) string {
+	names := []string{}
+	for name := range rl {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	resources := []string{}
+	for _, name := range names {
+		q := rl[corev1.ResourceName(name)]
+		resources = append(resources, name+"="+q.String())
+	}
+	return strings.Join(resources, ", ")
+}
+
+func volumeDetails(volumes []corev1.Volume) []volumeDetail {
+	details := []volumeDetail{}
+	for _, v := range volumes {
+		d := volumeDetail{Name: v.Name, Type: "---", Source: "---"}
+
+		switch {
+		case v.ConfigMap != nil:
+			d.Type, d.Source = "ConfigMap", v.ConfigMap.Name
+		case v.Secret != nil:
+			d.Type, d.Source = "Secret", v.Secret.SecretName
+		case v.PersistentVolumeClaim != nil:
+			d.Type, d.Source = "PersistentVolumeClaim", v.PersistentVolumeClaim.ClaimName
+		case v.HostPath != nil:
+			d.Type, d.Source = "HostPath", v.HostPath.Path
+		case v.EmptyDir != nil:
+			d.Type = "EmptyDir"
+		case v.Projected != nil:
+			d.Type = "Projected"
+		case v.DownwardAPI != nil:
+			d.Type = "DownwardAPI"
+		}
+
+		details = append(details, d)
+	}
+	return details
+}
@@ -16,8 +16,10 @@ package task
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -311,9 +313,10 @@ print     string   somethingdifferent
 output    array    [booms booms booms]
 
 Steps
-NAME
 hello
+  Image:   busybox
 exit
+  Image:   busybox
 
 Taskruns
 NAME   STARTED          DURATION    STATUS
@@ -360,3 +363,195 @@ func TestTaskDescribe_PipelineRunError(t *testing.T) {
 	test.AssertOutput(t, expected, out)
 	test.AssertOutput(t, "fake list taskrun error", err.Error())
 }
+
+func TestTaskDescribe_output(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Tasks: []*v1alpha1.Task{
+			tb.Task("task-1", "ns",
+				tb.TaskSpec(
+					tb.Step("build", "golang"),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+	task := Command(p)
+	out, err := test.ExecuteCommand(task, "desc", "task-1", "-n", "ns", "-o", "jsonpath={.apiVersion} {.spec.steps[0].image}")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "tekton.dev/v1alpha1 golang", out)
+}
+
+func TestTaskDescribe_step_details(t *testing.T) {
+	script := func(s string) tb.StepOp {
+		return func(step *v1alpha1.Step) {
+			step.Script = s
+		}
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Tasks: []*v1alpha1.Task{
+			tb.Task("task-1", "ns",
+				tb.TaskSpec(
+					tb.Step("build", "golang:1.13",
+						tb.StepCommand("go"),
+						tb.StepArgs("build", "-ldflags", "-X main.version=v0.1.0 -X main.commit=abcdef0123456789 -X main.date=2019-12-01", "./cmd/..."),
+						tb.StepWorkingDir("/workspace/src"),
+						tb.StepEnvVar("GOFLAGS", "-mod=vendor"),
+						tb.StepEnvVar("HOME", "/tekton/home"),
+						tb.StepResources(
+							tb.StepRequests(tb.StepCPU("500m"), tb.StepMemory("256Mi")),
+							tb.StepLimits(tb.StepMemory("1Gi")),
+						),
+						tb.StepVolumeMount("cache", "/go/pkg"),
+					),
+					tb.Step("", "alpine",
+						script("#!/bin/sh\nset -e\nfor f in /workspace/out/*; do\n\tsha256sum \"$f\"\ndone\necho done\nexit 0\n"),
+					),
+					tb.Sidecar("docker", "docker:dind",
+						tb.Args("--storage-driver=overlay2"),
+					),
+					tb.TaskVolume("cache", tb.VolumeSource(corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					})),
+					tb.TaskVolume("creds", tb.VolumeSource(corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "registry-creds"},
+					})),
+				),
+			),
+		},
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	out, err := test.ExecuteCommand(Command(p), "desc", "task-1", "-n", "ns")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:        task-1
+Namespace:   ns
+
+Input Resources
+No input resources
+
+Output Resources
+No output resources
+
+Params
+No params
+
+Steps
+build
+  Image:           golang:1.13
+  Command:         go
+  Args:            build -ldflags "-X main.version=v0.1.0 -X main.commit=abcdef0123456789 -X main.d...
+  Working Dir:     /workspace/src
+  Env:             GOFLAGS, HOME
+  Requests:        cpu=500m, memory=256Mi
+  Limits:          memory=1Gi
+  Volume Mounts:   cache:/go/pkg
+unnamed-1
+  Image:   alpine
+  Script:
+    #!/bin/sh
+    set -e
+    for f in /workspace/out/*; do
+        sha256sum "$f"
+    done
+    ... 2 more lines, use --full to see them all
+
+Volumes
+NAME    TYPE       SOURCE
+cache   EmptyDir   ---
+creds   Secret     registry-creds
+
+Sidecars
+docker
+  Image:   docker:dind
+  Args:    --storage-driver=overlay2
+
+Taskruns
+No taskruns
+`
+	test.AssertOutput(t, expected, out)
+
+	out, err = test.ExecuteCommand(Command(p), "desc", "task-1", "-n", "ns", "--full")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedFull := `Name:        task-1
+Namespace:   ns
+
+Input Resources
+No input resources
+
+Output Resources
+No output resources
+
+Params
+No params
+
+Steps
+build
+  Image:           golang:1.13
+  Command:         go
+  Args:            build -ldflags "-X main.version=v0.1.0 -X main.commit=abcdef0123456789 -X main.date=2019-12-01" ./cmd/...
+  Working Dir:     /workspace/src
+  Env:             GOFLAGS, HOME
+  Requests:        cpu=500m, memory=256Mi
+  Limits:          memory=1Gi
+  Volume Mounts:   cache:/go/pkg
+unnamed-1
+  Image:   alpine
+  Script:
+    #!/bin/sh
+    set -e
+    for f in /workspace/out/*; do
+        sha256sum "$f"
+    done
+    echo done
+    exit 0
+
+Volumes
+NAME    TYPE       SOURCE
+cache   EmptyDir   ---
+creds   Secret     registry-creds
+
+Sidecars
+docker
+  Image:   docker:dind
+  Args:    --storage-driver=overlay2
+
+Taskruns
+No taskruns
+`
+	test.AssertOutput(t, expectedFull, out)
+}
+
+func TestTruncate(t *testing.T) {
+	s := strings.Repeat("é", maxCommandLength+1)
+
+	got := truncate(s, false)
+	if !utf8.ValidString(got) {
+		t.Errorf("Expected the truncated string to be valid UTF-8: %q", got)
+	}
+	test.AssertOutput(t, strings.Repeat("é", maxCommandLength)+"...", got)
+	test.AssertOutput(t, s, truncate(s, true))
+	test.AssertOutput(t, "é", truncate("é", false))
+}
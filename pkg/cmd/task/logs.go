@@ -15,6 +15,7 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -61,6 +62,10 @@ Show logs of given Task for last TaskRun:
 Show logs for given task and taskrun:
 
     tkn task logs task taskrun -n namespace
+
+Search the logs of the last 20 runs of given Task for lines matching a regular expression:
+
+    tkn task logs task -n namespace --grep "connection (refused|reset)" --runs 20
 `
 	c := &cobra.Command{
 		Use:                   "logs",
@@ -87,19 +92,35 @@ Show logs for given task and taskrun:
 				return err
 			}
 
-			return run(opts, args)
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return run(ctx, opts, args)
 		},
 	}
 	c.Flags().BoolVarP(&opts.Last, "last", "L", false, "show logs for last taskrun")
 	c.Flags().BoolVarP(&opts.AllSteps, "all", "a", false, "show all logs including init steps injected by tekton")
 	c.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "stream live logs")
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "lists number of taskruns")
+	c.Flags().BoolVarP(&opts.Timestamps, "timestamps", "", false, "show the timestamp of each log line")
+	c.Flags().DurationVarP(&opts.Since, "since", "", 0, "only show logs newer than a relative duration like 30s, 10m or 2h")
+	c.Flags().StringVarP(&opts.SinceTime, "since-time", "", "", "only show logs after a date in RFC3339 format")
+	c.Flags().Int64VarP(&opts.Tail, "tail", "", 0, "number of most recent lines to show for each step (default: all)")
+	c.Flags().StringVarP(&opts.Output, "output", "o", "", "output format of the logs, json writes one JSON object per line (default: text)")
+	c.Flags().StringVarP(&opts.Grep, "grep", "", "", "search the logs of the last runs of the task for lines matching this regular expression")
+	c.Flags().IntVarP(&opts.Runs, "runs", "", 10, "number of runs searched with --grep")
+	c.Flags().StringVarP(&opts.Status, "status", "", "", "only search the runs with this status with --grep: succeeded, failed, running or cancelled")
+	c.Flags().IntVarP(&opts.Parallel, "parallel", "", 4, "number of runs searched at the same time with --grep")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_task")
 	return c
 }
 
-func run(opts *options.LogOptions, args []string) error {
+func run(ctx context.Context, opts *options.LogOptions, args []string) error {
+	if opts.Grep != "" {
+		return search(ctx, opts, args)
+	}
+
 	if err := initOpts(opts, args); err != nil {
 		return err
 	}
@@ -108,7 +129,16 @@ func run(opts *options.LogOptions, args []string) error {
 		return nil
 	}
 
-	return taskrun.Run(opts)
+	return taskrun.Run(ctx, opts)
+}
+
+func search(ctx context.Context, opts *options.LogOptions, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("--grep requires the name of the task whose runs are searched")
+	}
+	opts.TaskName = args[0]
+
+	return taskrun.SearchLogs(ctx, opts)
 }
 
 func initOpts(opts *options.LogOptions, args []string) error {
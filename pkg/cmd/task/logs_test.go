@@ -15,6 +15,8 @@
 package task
 
 import (
+	"bytes"
+	"context"
 	"testing"
 	"time"
 
@@ -508,8 +510,123 @@ func TestTaskLog2(t *testing.T) {
 				opts.AskOpts = prompt.WithStdio(stdio)
 				opts.Stream = &cli.Stream{Out: stdio.Out, Err: stdio.Err}
 
-				return run(opts, tp.prompt.CmdArgs)
+				return run(context.Background(), opts, tp.prompt.CmdArgs)
 			})
 		})
 	}
 }
+
+func TestTaskLog_grep(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	var (
+		trs   []*v1alpha1.TaskRun
+		pods  []*corev1.Pod
+		tasks []fake.Log
+	)
+
+	runs := []struct {
+		name    string
+		started time.Duration
+		logs    []string
+	}{
+		{"task-run-1", 20 * time.Minute, []string{"TEST OK", "PASS"}},
+		{"task-run-2", 10 * time.Minute, []string{"TEST FAILED: timeout", "FAIL"}},
+	}
+
+	for _, r := range runs {
+		pod := r.name + "-pod"
+		trs = append(trs, tb.TaskRun(r.name, "ns",
+			tb.TaskRunLabel("tekton.dev/task", "task"),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("task"),
+			),
+			tb.TaskRunStatus(
+				tb.PodName(pod),
+				tb.TaskRunStartTime(clock.Now().Add(-r.started)),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName("unit-test"),
+					tb.StateTerminated(0),
+				),
+			),
+		))
+
+		pods = append(pods, tb.Pod(pod, "ns",
+			tb.PodSpec(
+				tb.PodContainer("unit-test", "unit-test:latest"),
+			),
+		))
+
+		tasks = append(tasks, fake.Task(pod, fake.Step("unit-test", r.logs...)))
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		Tasks: []*v1alpha1.Task{
+			tb.Task("task", "ns"),
+		},
+		TaskRuns: trs,
+		Pods:     pods,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+	p.SetNamespace("ns")
+
+	out := new(bytes.Buffer)
+	opts := options.NewLogOptions(p)
+	opts.Stream = &cli.Stream{Out: out, Err: out}
+	opts.Streamer = fake.Streamer(fake.Logs(tasks...))
+	opts.Grep = "FAIL"
+	opts.Runs = 10
+	opts.Parallel = 1
+
+	if err := run(context.Background(), opts, []string{"task"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "task-run-2 (Succeeded, started 10 minutes ago)\n" +
+		"[unit-test] TEST FAILED: timeout\n" +
+		"[unit-test] FAIL\n" +
+		"\n" +
+		"RUN          STATUS      STARTED          MATCHES   \n" +
+		"task-run-2   Succeeded   10 minutes ago   2         \n" +
+		"task-run-1   Succeeded   20 minutes ago   0         \n" +
+		"\n" +
+		"First match in task-run-2, started 10 minutes ago\n"
+	test.AssertOutput(t, expected, out.String())
+
+	out.Reset()
+	opts.Grep = "panic"
+	if err := run(context.Background(), opts, []string{"task"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected = "RUN          STATUS      STARTED          MATCHES   \n" +
+		"task-run-2   Succeeded   10 minutes ago   0         \n" +
+		"task-run-1   Succeeded   20 minutes ago   0         \n" +
+		"\n" +
+		"No match for \"panic\"\n"
+	test.AssertOutput(t, expected, out.String())
+
+	// no run is searched once tkn is interrupted
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out.Reset()
+	err := run(ctx, opts, []string{"task"})
+	if err == nil {
+		t.Fatal("Expected an error for the interrupted search")
+	}
+	test.AssertOutput(t, "search interrupted, the results of the 0 runs searched out of 2 are partial", err.Error())
+	test.AssertOutput(t, "RUN   STATUS   STARTED   MATCHES   \n", out.String())
+}
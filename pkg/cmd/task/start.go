@@ -15,6 +15,7 @@
 package task
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -26,9 +27,12 @@ import (
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/cmd/taskrun"
 	"github.com/tektoncd/cli/pkg/flags"
+	"github.com/tektoncd/cli/pkg/helper/git"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	"github.com/tektoncd/cli/pkg/helper/labels"
 	"github.com/tektoncd/cli/pkg/helper/options"
 	"github.com/tektoncd/cli/pkg/helper/params"
+	"github.com/tektoncd/cli/pkg/helper/source"
 	"github.com/tektoncd/cli/pkg/helper/task"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -40,7 +44,12 @@ var (
 	errInvalidTask = "task name %s does not exist in namespace %s"
 )
 
-const invalidResource = "invalid input format for resource parameter: "
+const (
+	invalidResource = "invalid input format for resource parameter: "
+	// gitFromLocalDetect is the value of --git-from-local when no resource
+	// name is given, the git resource is then looked up in the task inputs
+	gitFromLocalDetect = "*"
+)
 
 type startOptions struct {
 	cliparams          cli.Params
@@ -54,6 +63,8 @@ type startOptions struct {
 	ShowLog            bool
 	Filename           string
 	TimeOut            int64
+	GitFromLocal       string
+	SourceDir          string
 }
 
 // NameArg validates that the first argument is a valid task name
@@ -105,6 +116,16 @@ or in a file using the --filename argument.
 
 For params value, if you want to provide multiple values, provide them comma separated
 like cat,foo,bar
+
+Bind the git input resource of Task foo to the remote url and HEAD commit of the
+git repository in the current directory:
+
+    tkn task start foo --git-from-local
+
+Upload the current directory, without the files ignored by git, and bind it
+as the git input resource of Task foo which is not bound with --inputresource:
+
+    tkn task start foo --source-dir ./
 `,
 		SilenceUsage: true,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -128,7 +149,10 @@ like cat,foo,bar
 				Err: cmd.OutOrStderr(),
 			}
 
-			return startTask(opt, args)
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return startTask(ctx, opt, args)
 		},
 	}
 
@@ -142,6 +166,9 @@ like cat,foo,bar
 	c.Flags().BoolVarP(&opt.ShowLog, "showlog", "", false, "show logs right after starting the task")
 	c.Flags().StringVarP(&opt.Filename, "filename", "f", "", "filename containing a task definition")
 	c.Flags().Int64VarP(&opt.TimeOut, "timeout", "t", 3600, "timeout for taskrun in seconds")
+	c.Flags().StringVar(&opt.GitFromLocal, "git-from-local", "", "bind the git input resource to the remote url and HEAD commit of the git repository in the current directory, optionally naming the resource")
+	c.Flags().Lookup("git-from-local").NoOptDefVal = gitFromLocalDetect
+	c.Flags().StringVar(&opt.SourceDir, "source-dir", "", "upload the given local directory to the cluster and bind it as the git input resource which is not bound with --inputresource")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_task")
 
@@ -160,7 +187,11 @@ func parseTask(p string) (*v1alpha1.Task, error) {
 	return &task, nil
 }
 
-func startTask(opt startOptions, args []string) error {
+func startTask(ctx context.Context, opt startOptions, args []string) error {
+	if opt.GitFromLocal != "" && opt.SourceDir != "" {
+		return errors.New("cannot use --git-from-local and --source-dir together")
+	}
+
 	tr := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: opt.cliparams.Namespace(),
@@ -168,31 +199,40 @@ func startTask(opt startOptions, args []string) error {
 	}
 
 	var tname string
+	var tspec *v1alpha1.TaskSpec
 	timeoutSeconds := time.Duration(opt.TimeOut) * time.Second
 
+	cs, err := opt.cliparams.Clients()
+	if err != nil {
+		return err
+	}
+
 	if len(args) > 0 {
 		tname = args[0]
 		tr.Spec = v1alpha1.TaskRunSpec{
 			TaskRef: &v1alpha1.TaskRef{Name: tname},
 			Timeout: &metav1.Duration{Duration: timeoutSeconds},
 		}
+		if opt.GitFromLocal != "" || opt.SourceDir != "" {
+			t, err := cs.Tekton.TektonV1alpha1().Tasks(opt.cliparams.Namespace()).Get(tname, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			tspec = &t.Spec
+		}
 	} else {
 		task, err := parseTask(opt.Filename)
 		if err != nil {
 			return err
 		}
 		tname = task.ObjectMeta.Name
+		tspec = &task.Spec
 		tr.Spec = v1alpha1.TaskRunSpec{
 			TaskSpec: &task.Spec,
 		}
 	}
 	tr.ObjectMeta.GenerateName = tname + "-run-"
 
-	cs, err := opt.cliparams.Clients()
-	if err != nil {
-		return err
-	}
-
 	if opt.Last {
 		trLast, err := task.LastRun(cs.Tekton, tname, opt.cliparams.Namespace())
 		if err != nil {
@@ -209,6 +249,23 @@ func startTask(opt startOptions, args []string) error {
 	}
 	tr.Spec.Inputs.Resources = inputRes
 
+	if opt.GitFromLocal != "" {
+		binding, err := localGitBinding(opt, tname, tspec)
+		if err != nil {
+			return err
+		}
+		tr.Spec.Inputs.Resources = mergeBindings(tr.Spec.Inputs.Resources, map[string]v1alpha1.TaskResourceBinding{
+			binding.Name: *binding,
+		})
+	}
+
+	sourceRes := ""
+	if opt.SourceDir != "" {
+		if sourceRes, err = sourceDirResource(opt, tname, tspec); err != nil {
+			return err
+		}
+	}
+
 	outRes, err := mergeRes(tr.Spec.Outputs.Resources, opt.OutputResources)
 	if err != nil {
 		return err
@@ -220,6 +277,7 @@ func startTask(opt startOptions, args []string) error {
 		return err
 	}
 	tr.ObjectMeta.Labels = labels
+	invoker.Current(opt.cliparams).Annotate(&tr.ObjectMeta)
 
 	param, err := params.MergeParam(tr.Spec.Inputs.Params, opt.Params)
 	if err != nil {
@@ -231,11 +289,41 @@ func startTask(opt startOptions, args []string) error {
 		tr.Spec.ServiceAccountName = opt.ServiceAccountName
 	}
 
+	var upload *source.Upload
+	if sourceRes != "" {
+		if upload, err = source.UploadDir(opt.stream.Out, cs.Kube, opt.cliparams.Namespace(), opt.SourceDir); err != nil {
+			return err
+		}
+		tr.Spec.Inputs.Resources = mergeBindings(tr.Spec.Inputs.Resources, map[string]v1alpha1.TaskResourceBinding{
+			sourceRes: {
+				PipelineResourceBinding: v1alpha1.PipelineResourceBinding{
+					Name:         sourceRes,
+					ResourceSpec: upload.Spec(),
+				},
+			},
+		})
+	}
+
 	trCreated, err := cs.Tekton.TektonV1alpha1().TaskRuns(opt.cliparams.Namespace()).Create(tr)
 	if err != nil {
+		if upload != nil {
+			_ = upload.Delete()
+		}
 		return err
 	}
 
+	if upload != nil {
+		owner := metav1.OwnerReference{
+			APIVersion: "tekton.dev/v1alpha1",
+			Kind:       "TaskRun",
+			Name:       trCreated.Name,
+			UID:        trCreated.UID,
+		}
+		if err := upload.SetOwner(owner); err != nil {
+			fmt.Fprintf(opt.stream.Err, "Warning: source %s will not be deleted along with the taskrun: %s\n", upload.Name, err)
+		}
+	}
+
 	fmt.Fprintf(opt.stream.Out, "Taskrun started: %s\n", trCreated.Name)
 	if !opt.ShowLog {
 		fmt.Fprintf(opt.stream.Out, "\nIn order to track the taskrun progress run:\ntkn taskrun logs %s -f -n %s\n", trCreated.Name, trCreated.Namespace)
@@ -250,7 +338,7 @@ func startTask(opt startOptions, args []string) error {
 		Params:      opt.cliparams,
 		AllSteps:    false,
 	}
-	return taskrun.Run(runLogOpts)
+	return taskrun.Run(ctx, runLogOpts)
 }
 
 func mergeRes(r []v1alpha1.TaskResourceBinding, optRes []string) ([]v1alpha1.TaskResourceBinding, error) {
@@ -259,8 +347,12 @@ func mergeRes(r []v1alpha1.TaskResourceBinding, optRes []string) ([]v1alpha1.Tas
 		return nil, err
 	}
 
+	return mergeBindings(r, res), nil
+}
+
+func mergeBindings(r []v1alpha1.TaskResourceBinding, res map[string]v1alpha1.TaskResourceBinding) []v1alpha1.TaskResourceBinding {
 	if len(res) == 0 {
-		return r, nil
+		return r
 	}
 
 	for i := range r {
@@ -272,7 +364,71 @@ func mergeRes(r []v1alpha1.TaskResourceBinding, optRes []string) ([]v1alpha1.Tas
 	for _, v := range res {
 		r = append(r, v)
 	}
-	return r, nil
+	return r
+}
+
+// localGitBinding binds the git input resource of the task to the remote
+// and HEAD revision of the git repository in the current directory
+func localGitBinding(opt startOptions, tname string, spec *v1alpha1.TaskSpec) (*v1alpha1.TaskResourceBinding, error) {
+	declared := map[string]v1alpha1.PipelineResourceType{}
+	if spec.Inputs != nil {
+		for _, res := range spec.Inputs.Resources {
+			declared[res.Name] = res.Type
+		}
+	}
+
+	name := opt.GitFromLocal
+	if name == gitFromLocalDetect {
+		name = ""
+	}
+
+	name, err := git.FindResourceName(name, declared)
+	if err != nil {
+		return nil, fmt.Errorf("task %s: %s", tname, err)
+	}
+
+	local, err := git.FromLocal(".")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range local.Warnings {
+		fmt.Fprintf(opt.stream.Err, "Warning: %s\n", w)
+	}
+
+	return &v1alpha1.TaskResourceBinding{
+		PipelineResourceBinding: v1alpha1.PipelineResourceBinding{
+			Name:         name,
+			ResourceSpec: local.Spec(),
+		},
+	}, nil
+}
+
+// sourceDirResource finds the git input resource of the task which is not
+// bound with --inputresource, to be bound to the uploaded source directory
+func sourceDirResource(opt startOptions, tname string, spec *v1alpha1.TaskSpec) (string, error) {
+	bound, err := parseRes(opt.InputResources)
+	if err != nil {
+		return "", err
+	}
+
+	declared := map[string]v1alpha1.PipelineResourceType{}
+	if spec.Inputs != nil {
+		for _, res := range spec.Inputs.Resources {
+			if _, ok := bound[res.Name]; !ok {
+				declared[res.Name] = res.Type
+			}
+		}
+	}
+
+	name, err := git.FindResourceName("", declared)
+	if err == git.ErrManyResources {
+		return "", fmt.Errorf("task %s: more than one git input resource is not bound, bind the others with --inputresource", tname)
+	}
+	if err != nil {
+		return "", fmt.Errorf("task %s: %s", tname, err)
+	}
+	return name, nil
 }
 
 func parseRes(res []string) (map[string]v1alpha1.TaskResourceBinding, error) {
@@ -16,8 +16,13 @@ package task
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/cli/pkg/test"
@@ -254,6 +259,177 @@ func Test_start_task(t *testing.T) {
 	test.AssertOutput(t, "svc1", tr.Items[0].Spec.ServiceAccountName)
 }
 
+func Test_start_task_git_from_local(t *testing.T) {
+	tasks := []*v1alpha1.Task{
+		tb.Task("task-1", "ns",
+			tb.TaskSpec(
+				tb.TaskInputs(
+					tb.InputsResource("my-repo", v1alpha1.PipelineResourceTypeGit),
+					tb.InputsResource("my-image", v1alpha1.PipelineResourceTypeImage),
+				),
+				tb.Step("hello", "busybox"),
+			),
+		),
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "tkn-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.email=tkn@example.com", "-c", "user.name=tkn", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"remote", "add", "origin", "https://github.com/tektoncd/cli"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	rev, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Tasks: tasks, Namespaces: ns})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	task := Command(p)
+	got, _ := test.ExecuteCommand(task, "start", "task-1",
+		"--git-from-local",
+		"-i=my-image=image",
+		"-n=ns")
+
+	branch, _ := exec.Command("git", "-C", dir, "symbolic-ref", "--short", "HEAD").Output()
+	expected := "Warning: " + strings.TrimSpace(string(branch)) + " has commits which are not pushed to origin, revision " +
+		strings.TrimSpace(string(rev)) + " may not be available to the cluster\n" +
+		"Taskrun started: \n\nIn order to track the taskrun progress run:\ntkn taskrun logs  -f -n ns\n"
+	test.AssertOutput(t, expected, got)
+
+	tr, err := cs.Pipeline.TektonV1alpha1().TaskRuns("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Errorf("Error listing taskruns %s", err.Error())
+	}
+
+	test.AssertOutput(t, 2, len(tr.Items[0].Spec.Inputs.Resources))
+	for _, v := range tr.Items[0].Spec.Inputs.Resources {
+		if v.Name == "my-repo" {
+			test.AssertOutput(t, &v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.ResourceParam{
+					{Name: "url", Value: "https://github.com/tektoncd/cli"},
+					{Name: "revision", Value: strings.TrimSpace(string(rev))},
+				},
+			}, v.ResourceSpec)
+		}
+	}
+}
+
+func Test_start_task_source_dir(t *testing.T) {
+	tasks := []*v1alpha1.Task{
+		tb.Task("task-1", "ns",
+			tb.TaskSpec(
+				tb.TaskInputs(
+					tb.InputsResource("my-repo", v1alpha1.PipelineResourceTypeGit),
+					tb.InputsResource("my-image", v1alpha1.PipelineResourceTypeImage),
+				),
+				tb.Step("hello", "busybox"),
+			),
+		),
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "tkn-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(dir+"/main.go", []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Tasks: tasks, Namespaces: ns})
+	watcher := watch.NewFake()
+	cs.Kube.PrependWatchReactor("pods", k8stest.DefaultWatchReactor(watcher, nil))
+	cs.Kube.PrependReactor("create", "pods", func(action k8stest.Action) (bool, runtime.Object, error) {
+		created := action.(k8stest.CreateAction).GetObject().(*corev1.Pod)
+		created.Status.Phase = corev1.PodRunning
+		created.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}
+		pod := created.DeepCopy()
+		go func() {
+			time.Sleep(time.Second)
+			watcher.Modify(pod)
+		}()
+		return false, nil, nil
+	})
+	p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+	task := Command(p)
+	got, err := test.ExecuteCommand(task, "start", "task-1",
+		"--source-dir", dir,
+		"-i=my-image=image",
+		"-n=ns")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	svcs, err := cs.Kube.CoreV1().Services("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 1, len(svcs.Items))
+	url := "git://" + svcs.Items[0].Name + ".ns.svc:9418/source"
+
+	expected := "Source uploaded from " + dir + " to " + url + "\n" +
+		"Taskrun started: \n\nIn order to track the taskrun progress run:\ntkn taskrun logs  -f -n ns\n"
+	test.AssertOutput(t, expected, got)
+
+	tr, err := cs.Pipeline.TektonV1alpha1().TaskRuns("ns").List(v1.ListOptions{})
+	if err != nil {
+		t.Errorf("Error listing taskruns %s", err.Error())
+	}
+
+	test.AssertOutput(t, 2, len(tr.Items[0].Spec.Inputs.Resources))
+	for _, v := range tr.Items[0].Spec.Inputs.Resources {
+		if v.Name == "my-repo" {
+			test.AssertOutput(t, &v1alpha1.PipelineResourceSpec{
+				Type: v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.ResourceParam{
+					{Name: "url", Value: url},
+					{Name: "revision", Value: "master"},
+				},
+			}, v.ResourceSpec)
+		}
+	}
+	test.AssertOutput(t, "TaskRun", svcs.Items[0].OwnerReferences[0].Kind)
+}
+
 func Test_start_task_last(t *testing.T) {
 	tasks := []*v1alpha1.Task{
 		tb.Task("task", "ns",
@@ -16,17 +16,25 @@ package taskrun
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/events"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/results"
+	thelper "github.com/tektoncd/cli/pkg/helper/task"
+	trlist "github.com/tektoncd/cli/pkg/helper/taskrun/list"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	"github.com/tektoncd/cli/pkg/printer"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const templ = `Name:	{{ .TaskRun.Name }}
@@ -37,6 +45,12 @@ Task Ref:    {{ $tRefName }}
 {{- if ne .TaskRun.Spec.ServiceAccountName "" }}
 Service Account:	{{ .TaskRun.Spec.ServiceAccountName }}
 {{- end }}
+{{- if ne .TaskRun.Status.PodName "" }}
+Pod:	{{ .TaskRun.Status.PodName }}
+{{- end }}
+{{- if ne .Node "" }}
+Node:	{{ .Node }}
+{{- end }}
 
 Status
 STARTED 	DURATION 	STATUS
@@ -76,6 +90,15 @@ NAME	RESOURCE REF
 {{- end }}
 {{- end }}
 
+{{- $l := len .Results }}{{ if ne $l 0 }}
+
+Results
+RESOURCE	KEY	VALUE
+{{- range $r := .Results }}
+{{ $r.Resource }}	{{ $r.Key }}	{{ $r.Value }}
+{{- end }}
+{{- end }}
+
 Params
 {{- $l := len .TaskRun.Spec.Inputs.Params }}{{ if eq $l 0 }}
 No params
@@ -91,19 +114,54 @@ NAME	VALUE
 {{- end }}
 
 Steps
-{{- $l := len .TaskRun.Status.Steps }}{{ if eq $l 0 }}
+{{- $l := len .Steps }}{{ if eq $l 0 }}
 No steps
 {{- else }}
-NAME	STATUS
-{{- range $step := .TaskRun.Status.Steps }}
-{{- $reason := stepReasonExists $step }}
-{{ $step.Name }}	{{ $reason }}
+NAME	STATUS	EXIT CODE	STARTED	FINISHED	DURATION
+{{- range $step := .Steps }}
+{{- $reason := stepReasonExists $step.StepState }}
+{{ $step.Name }}	{{ $reason }}	{{ $step.ExitCode }}	{{ formatAge $step.Started $.Params.Time }}	{{ formatAge $step.Finished $.Params.Time }}	{{ formatDuration $step.Started $step.Finished }}
+{{- end }}
+
+Step Images
+NAME	IMAGE	IMAGE ID
+{{- range $step := .Steps }}
+{{ $step.Name }}	{{ or $step.Image "---" }}	{{ or $step.ImageID "---" }}
+{{- end }}
+{{- end }}
+{{- $l := len .Sidecars }}{{ if ne $l 0 }}
+
+Sidecars
+NAME	STATUS	IMAGE	IMAGE ID
+{{- range $sc := .Sidecars }}
+{{ $sc.Name }}	{{ $sc.Status }}	{{ or $sc.Image "---" }}	{{ or $sc.ImageID "---" }}
+{{- end }}
+{{- end }}
+{{- $l := len .TaskRun.Status.RetriesStatus }}{{ if ne $l 0 }}
+
+Retries
+ATTEMPT	POD	STARTED	DURATION	STATUS
+{{- range $i, $r := .TaskRun.Status.RetriesStatus }}
+{{ attempt $i }}	{{ or $r.PodName "---" }}	{{ formatAge $r.StartTime $.Params.Time }}	{{ formatDuration $r.StartTime $r.CompletionTime }}	{{ formatCondition $r.Conditions }}
+{{- end }}
+{{- end }}
+{{- if .ShowEvents }}
+
+Events
+{{- $l := len .Events }}{{ if eq $l 0 }}
+No events
+{{- else }}
+LAST SEEN	TYPE	REASON	OBJECT	MESSAGE
+{{- range $e := .Events }}
+{{ $e.Seen $.Params.Time }}	{{ $e.Type }}	{{ $e.Reason }}	{{ $e.Object }}	{{ $e.Message }}
+{{- end }}
 {{- end }}
 {{- end }}
 `
 
 func describeCommand(p cli.Params) *cobra.Command {
-	f := cliopts.NewPrintFlags("describe")
+	opts := options.NewDescribeOptions()
+	showEvents := true
 	eg := `Describe a TaskRun of name 'foo' in namespace 'bar':
 
     tkn taskrun describe foo -n bar
@@ -111,6 +169,10 @@ func describeCommand(p cli.Params) *cobra.Command {
 or
 
     tkn tr desc foo -n bar
+
+Describe the last TaskRun of the Task 'foo' in namespace 'bar':
+
+    tkn tr desc --last --task foo -n bar
 `
 
 	c := &cobra.Command{
@@ -118,7 +180,7 @@ or
 		Aliases:      []string{"desc"},
 		Short:        "Describe a taskrun in a namespace",
 		Example:      eg,
-		Args:         cobra.MinimumNArgs(1),
+		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
 		Annotations: map[string]string{
 			"commandType": "main",
@@ -133,17 +195,74 @@ or
 				return err
 			}
 
-			return printTaskRunDescription(s, args[0], p)
+			trName, err := taskRunName(p, opts, args)
+			if err != nil {
+				return err
+			}
+
+			return printTaskRunDescription(s, trName, p, showEvents, opts)
 		},
 	}
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_taskrun")
-	f.AddFlags(c)
+	opts.AddFlags(c)
+	c.Flags().BoolVarP(&opts.Last, "last", "L", false, "describe the most recent taskrun")
+	c.Flags().StringVarP(&opts.TaskName, "task", "", "", "only describe or select the taskruns of this task")
+	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "number of taskruns to select from when no name is given")
+	c.Flags().BoolVarP(&showEvents, "events", "", true, "show the events of the taskrun and its pods")
 
 	return c
 }
 
-func printTaskRunDescription(s *cli.Stream, trName string, p cli.Params) error {
+// taskRunName returns the name of the taskrun to describe: the one given, the
+// last one with --last or the one selected in a prompt
+func taskRunName(p cli.Params, opts *options.DescribeOptions, args []string) (string, error) {
+	if len(args) != 0 {
+		if opts.Last {
+			return "", fmt.Errorf("--last cannot be used with the name of a taskrun")
+		}
+		return args[0], nil
+	}
+
+	if opts.Last {
+		cs, err := p.Clients()
+		if err != nil {
+			return "", fmt.Errorf("failed to create tekton client")
+		}
+
+		tr, err := thelper.LastRun(cs.Tekton, opts.TaskName, p.Namespace())
+		if err != nil {
+			return "", err
+		}
+		return tr.Name, nil
+	}
+
+	if !opts.Interactive {
+		return "", fmt.Errorf("taskrun name required, or use --last to describe the most recent one")
+	}
+
+	lOpts := metav1.ListOptions{}
+	if opts.TaskName != "" {
+		lOpts.LabelSelector = fmt.Sprintf("tekton.dev/task=%s", opts.TaskName)
+	}
+
+	trs, err := trlist.GetAllTaskRuns(p, lOpts, opts.Limit)
+	if err != nil {
+		return "", err
+	}
+
+	if len(trs) == 0 {
+		return "", fmt.Errorf("No taskruns found")
+	}
+
+	if len(trs) == 1 {
+		return strings.Fields(trs[0])[0], nil
+	}
+
+	return opts.Ask(options.ResourceNameTaskRun, trs)
+}
+
+func printTaskRunDescription(s *cli.Stream, trName string, p cli.Params, showEvents bool, opts *options.DescribeOptions) error {
 	cs, err := p.Clients()
 	if err != nil {
 		return fmt.Errorf("failed to create tekton client")
@@ -154,12 +273,59 @@ func printTaskRunDescription(s *cli.Stream, trName string, p cli.Params) error {
 		return fmt.Errorf("failed to find taskrun %q", trName)
 	}
 
+	if opts.Output() != "" {
+		// NOTE: this is required for -o json|yaml to work properly since
+		// tektoncd go client fails to set these; probably a bug
+		tr.GetObjectKind().SetGroupVersionKind(
+			schema.GroupVersionKind{
+				Version: "tekton.dev/v1alpha1",
+				Kind:    "TaskRun",
+			})
+		return printer.PrintObject(s.Out, tr, opts.PrintFlags)
+	}
+
+	var evs []events.Event
+	if showEvents {
+		objects := []corev1.ObjectReference{
+			{Kind: "TaskRun", Name: tr.Name, UID: tr.UID},
+			{Kind: "Pod", Name: tr.Status.PodName},
+		}
+		for _, rs := range tr.Status.RetriesStatus {
+			objects = append(objects, corev1.ObjectReference{Kind: "Pod", Name: rs.PodName})
+		}
+
+		if evs, err = events.List(cs.Kube, p.Namespace(), objects...); err != nil {
+			fmt.Fprintf(s.Err, "Failed to list the events of taskrun %s: %s\n", tr.Name, err)
+			showEvents = false
+		}
+	}
+
+	var pod *corev1.Pod
+	if tr.Status.PodName != "" {
+		// the details of the pod are left out once it has been deleted
+		if pod, err = cs.Kube.CoreV1().Pods(p.Namespace()).Get(tr.Status.PodName, metav1.GetOptions{}); err != nil {
+			pod = nil
+		}
+	}
+
 	var data = struct {
-		TaskRun *v1alpha1.TaskRun
-		Params  cli.Params
+		TaskRun    *v1alpha1.TaskRun
+		Params     cli.Params
+		Node       string
+		Steps      []stepDetail
+		Sidecars   []sidecarDetail
+		Results    []results.Result
+		ShowEvents bool
+		Events     []events.Event
 	}{
-		TaskRun: tr,
-		Params:  p,
+		TaskRun:    tr,
+		Params:     p,
+		Node:       nodeName(pod),
+		Steps:      stepDetails(tr, pod),
+		Sidecars:   sidecarDetails(tr, pod),
+		Results:    results.TaskRun(&tr.Status),
+		ShowEvents: showEvents,
+		Events:     evs,
 	}
 
 	funcMap := template.FuncMap{
@@ -170,10 +336,15 @@ func printTaskRunDescription(s *cli.Stream, trName string, p cli.Params) error {
 		"taskRefExists":         validate.TaskRefExists,
 		"taskResourceRefExists": validate.TaskResourceRefExists,
 		"stepReasonExists":      validate.StepReasonExists,
+		"attempt":               func(i int) int { return i + 1 },
+	}
+
+	t, err := opts.Template("Describe taskrun", templ, funcMap)
+	if err != nil {
+		return err
 	}
 
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("Describe taskrun").Funcs(funcMap).Parse(templ))
 
 	err = t.Execute(w, data)
 	if err != nil {
@@ -194,3 +365,100 @@ func hasFailed(tr *v1alpha1.TaskRun) string {
 
 	return ""
 }
+
+// stepDetail is the state of a step along with the image of its container
+type stepDetail struct {
+	v1alpha1.StepState
+	Image string
+}
+
+// ExitCode is the exit code of the step once it has terminated
+func (s stepDetail) ExitCode() string {
+	if s.Terminated == nil {
+		return "---"
+	}
+	return strconv.Itoa(int(s.Terminated.ExitCode))
+}
+
+// Started is when the container of the step started
+func (s stepDetail) Started() *metav1.Time {
+	switch {
+	case s.Running != nil:
+		return &s.Running.StartedAt
+	case s.Terminated != nil:
+		return &s.Terminated.StartedAt
+	}
+	return nil
+}
+
+// Finished is when the container of the step terminated
+func (s stepDetail) Finished() *metav1.Time {
+	if s.Terminated == nil {
+		return nil
+	}
+	return &s.Terminated.FinishedAt
+}
+
+// sidecarDetail is a sidecar of the taskrun with the status and the image of its
+// container
+type sidecarDetail struct {
+	v1alpha1.SidecarState
+	Status string
+	Image  string
+}
+
+func stepDetails(tr *v1alpha1.TaskRun, pod *corev1.Pod) []stepDetail {
+	steps := []stepDetail{}
+	for _, s := range tr.Status.Steps {
+		name := s.ContainerName
+		if name == "" {
+			name = "step-" + s.Name
+		}
+		steps = append(steps, stepDetail{
+			StepState: s,
+			Image:     containerImage(pod, name),
+		})
+	}
+	return steps
+}
+
+func sidecarDetails(tr *v1alpha1.TaskRun, pod *corev1.Pod) []sidecarDetail {
+	sidecars := []sidecarDetail{}
+	for _, s := range tr.Status.Sidecars {
+		name := sidecarPrefix + s.Name
+		status := "---"
+		if pod != nil {
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.Name == name {
+					status = validate.StepReasonExists(v1alpha1.StepState{ContainerState: cs.State})
+				}
+			}
+		}
+
+		sidecars = append(sidecars, sidecarDetail{
+			SidecarState: s,
+			Status:       status,
+			Image:        containerImage(pod, name),
+		})
+	}
+	return sidecars
+}
+
+func containerImage(pod *corev1.Pod, name string) string {
+	if pod == nil {
+		return ""
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+func nodeName(pod *corev1.Pod) string {
+	if pod == nil {
+		return ""
+	}
+	return pod.Spec.NodeName
+}
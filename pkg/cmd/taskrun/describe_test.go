@@ -15,12 +15,20 @@
 package taskrun
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/AlecAivazis/survey/v2/terminal"
+	goexpect "github.com/Netflix/go-expect"
 	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	"github.com/tektoncd/cli/pkg/test"
 	cb "github.com/tektoncd/cli/pkg/test/builder"
+	"github.com/tektoncd/cli/test/prompt"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	pipelinetest "github.com/tektoncd/pipeline/test"
@@ -28,13 +36,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 var (
 	reasonCompleted = corev1.ContainerStateTerminated{Reason: "Completed"}
 	reasonWaiting   = corev1.ContainerStateWaiting{Reason: "PodInitializing"}
 	reasonFailed    = corev1.ContainerStateTerminated{Reason: "Error"}
-	reasonRunning   = corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: time.Now()}}
+	reasonRunning   = corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: clockwork.NewFakeClock().Now()}}
 )
 
 func TestTaskRunDescribe_invalid_namespace(t *testing.T) {
@@ -133,6 +142,9 @@ No params
 
 Steps
 No steps
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -213,9 +225,17 @@ input    param
 input2   param2
 
 Steps
-NAME    STATUS
-step1   Completed
-step2   Completed
+NAME    STATUS      EXIT CODE   STARTED   FINISHED   DURATION
+step1   Completed   0           ---       ---        ---
+step2   Completed   0           ---       ---        ---
+
+Step Images
+NAME    IMAGE   IMAGE ID
+step1   ---     ---
+step2   ---     ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -282,6 +302,9 @@ No params
 
 Steps
 No steps
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -344,6 +367,9 @@ No params
 
 Steps
 No steps
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -424,9 +450,17 @@ input    param
 input2   param2
 
 Steps
-NAME    STATUS
-step1   Completed
-step2   Completed
+NAME    STATUS      EXIT CODE   STARTED   FINISHED   DURATION
+step1   Completed   0           ---       ---        ---
+step2   Completed   0           ---       ---        ---
+
+Step Images
+NAME    IMAGE   IMAGE ID
+step1   ---     ---
+step2   ---     ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -506,9 +540,17 @@ input    param
 input2   param2
 
 Steps
-NAME    STATUS
-step1   Error
-step2   ---
+NAME    STATUS   EXIT CODE   STARTED   FINISHED   DURATION
+step1   Error    0           ---       ---        ---
+step2   ---      ---         ---       ---        ---
+
+Step Images
+NAME    IMAGE   IMAGE ID
+step1   ---     ---
+step2   ---     ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -590,9 +632,17 @@ input    param
 input2   param2
 
 Steps
-NAME    STATUS
-step1   PodInitializing
-step2   PodInitializing
+NAME    STATUS            EXIT CODE   STARTED   FINISHED   DURATION
+step1   PodInitializing   ---         ---       ---        ---
+step2   PodInitializing   ---         ---       ---        ---
+
+Step Images
+NAME    IMAGE   IMAGE ID
+step1   ---     ---
+step2   ---     ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -674,9 +724,17 @@ input    param
 input2   param2
 
 Steps
-NAME    STATUS
-step1   Running
-step2   Running
+NAME    STATUS    EXIT CODE   STARTED          FINISHED   DURATION
+step1   Running   ---         10 minutes ago   ---        ---
+step2   Running   ---         10 minutes ago   ---        ---
+
+Step Images
+NAME    IMAGE   IMAGE ID
+step1   ---     ---
+step2   ---     ---
+
+Events
+No events
 `
 
 	test.AssertOutput(t, expected, actual)
@@ -739,7 +797,567 @@ No params
 
 Steps
 No steps
+
+Events
+No events
+`
+
+	test.AssertOutput(t, expected, actual)
+}
+
+func TestTaskRunDescribe_events(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("tr-1", "ns",
+			tb.TaskRunStatus(
+				tb.PodName("tr-1-pod"),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: "Pending",
+				}),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("t1"),
+			),
+		),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		TaskRuns: trs,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	events := []*corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-1-pod"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Failed",
+			Message:        `Failed to pull image "golang:nope"`,
+			Count:          3,
+			LastTimestamp:  metav1.NewTime(clock.Now().Add(-time.Minute)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-1-pod"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Scheduled",
+			Message:        "Successfully assigned ns/tr-1-pod to node-1",
+			LastTimestamp:  metav1.NewTime(clock.Now().Add(-2 * time.Minute)),
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e3", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tr-2-pod"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Scheduled",
+			Message:        "Successfully assigned ns/tr-2-pod to node-1",
+			LastTimestamp:  metav1.NewTime(clock.Now()),
+		},
+	}
+	for _, e := range events {
+		if _, err := cs.Kube.CoreV1().Events("ns").Create(e); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+	actual, err := test.ExecuteCommand(Command(p), "desc", "tr-1", "-n", "ns")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:        tr-1
+Namespace:   ns
+Task Ref:    t1
+Pod:   tr-1-pod
+
+Status
+STARTED    DURATION    STATUS
+---        ---         Running(Pending)
+
+Input Resources
+No resources
+
+Output Resources
+No resources
+
+Params
+No params
+
+Steps
+No steps
+
+Events
+LAST SEEN           TYPE      REASON      OBJECT         MESSAGE
+2 minutes ago       Normal    Scheduled   Pod/tr-1-pod   Successfully assigned ns/tr-1-pod to node-1
+1 minute ago (x3)   Warning   Failed      Pod/tr-1-pod   Failed to pull image "golang:nope"
+`
+	test.AssertOutput(t, expected, actual)
+
+	actual, err = test.ExecuteCommand(Command(p), "desc", "tr-1", "-n", "ns", "--events=false")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected = `Name:        tr-1
+Namespace:   ns
+Task Ref:    t1
+Pod:   tr-1-pod
+
+Status
+STARTED    DURATION    STATUS
+---        ---         Running(Pending)
+
+Input Resources
+No resources
+
+Output Resources
+No resources
+
+Params
+No params
+
+Steps
+No steps
+`
+	test.AssertOutput(t, expected, actual)
+}
+
+func TestTaskRunDescribe_output(t *testing.T) {
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("tr-1", "ns",
+			tb.TaskRunLabel("tekton.dev/task", "t1"),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("t1"),
+				tb.TaskRunServiceAccountName("builder"),
+			),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Status: corev1.ConditionTrue,
+					Reason: resources.ReasonSucceeded,
+				}),
+			),
+		),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		TaskRuns: trs,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	dir, err := ioutil.TempDir("", "tkn-describe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpl := filepath.Join(dir, "describe.tmpl")
+	if err := ioutil.WriteFile(tmpl, []byte(`{{ .TaskRun.Name }} ran {{ taskRefExists .TaskRun.Spec }} as {{ .TaskRun.Spec.ServiceAccountName }}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.tmpl")
+	if err := ioutil.WriteFile(invalid, []byte(`{{ .TaskRun.Name `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "JSON path",
+			command: []string{"desc", "tr-1", "-n", "ns", "-o", "jsonpath={.kind}/{.metadata.name} {.status.conditions[0].reason}"},
+			want:    "TaskRun/tr-1 Succeeded",
+		},
+		{
+			name:    "Template file",
+			command: []string{"desc", "tr-1", "-n", "ns", "--template-file", tmpl},
+			want:    "tr-1 ran t1 as builder\n",
+		},
+		{
+			name:      "Invalid template file",
+			command:   []string{"desc", "tr-1", "-n", "ns", "--template-file", invalid},
+			wantError: true,
+			want:      "failed to parse template file " + invalid + ": template: Describe taskrun:1: unclosed action",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+
+			out, err := test.ExecuteCommand(Command(p), tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
+
+func describeRuns(t *testing.T, clock clockwork.Clock) pipelinetest.Clients {
+	var trs []*v1alpha1.TaskRun
+	for _, r := range []struct {
+		name    string
+		task    string
+		started time.Duration
+	}{
+		{"tr-1", "task", 10 * time.Minute},
+		{"tr-2", "task", 5 * time.Minute},
+		{"tr-3", "other", time.Minute},
+	} {
+		trs = append(trs, tb.TaskRun(r.name, "ns",
+			cb.TaskRunCreationTime(clock.Now().Add(-r.started)),
+			tb.TaskRunLabel("tekton.dev/task", r.task),
+			tb.TaskRunSpec(tb.TaskRunTaskRef(r.task)),
+			tb.TaskRunStatus(
+				tb.TaskRunStartTime(clock.Now().Add(-r.started)),
+			),
+		))
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		TaskRuns: trs,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+	return cs
+}
+
+func TestTaskRunDescribe_last(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	cs := describeRuns(t, clock)
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Last taskrun",
+			command: []string{"desc", "--last", "-n", "ns", "-o", "jsonpath={.metadata.name}"},
+			want:    "tr-3",
+		},
+		{
+			name:    "Last taskrun of task",
+			command: []string{"desc", "--last", "--task", "task", "-n", "ns", "-o", "jsonpath={.metadata.name}"},
+			want:    "tr-2",
+		},
+		{
+			name:      "No taskrun of task",
+			command:   []string{"desc", "--last", "--task", "nope", "-n", "ns"},
+			wantError: true,
+			want:      "no taskruns related to task nope found in namespace ns",
+		},
+		{
+			name:      "Last and name",
+			command:   []string{"desc", "tr-1", "--last", "-n", "ns"},
+			wantError: true,
+			want:      "--last cannot be used with the name of a taskrun",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+			out, err := test.ExecuteCommand(Command(p), tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
+
+func Test_taskRunName(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	cs := describeRuns(t, clock)
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+	p.SetNamespace("ns")
+
+	opts := options.NewDescribeOptions()
+	opts.Interactive = false
+	if _, err := taskRunName(p, opts, nil); err == nil {
+		t.Fatal("error expected here")
+	} else {
+		test.AssertOutput(t, "taskrun name required, or use --last to describe the most recent one", err.Error())
+	}
+
+	opts.Interactive = true
+	opts.TaskName = "other"
+	name, err := taskRunName(p, opts, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "tr-3", name)
+
+	opts.TaskName = "task"
+	pr := prompt.Prompt{
+		Procedure: func(c *goexpect.Console) error {
+			if _, err := c.ExpectString("Select taskrun:"); err != nil {
+				return err
+			}
+			if _, err := c.ExpectString("tr-1 started 10 minutes ago"); err != nil {
+				return err
+			}
+			if _, err := c.SendLine(string(terminal.KeyArrowDown)); err != nil {
+				return err
+			}
+			if _, err := c.ExpectEOF(); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	pr.RunTest(t, pr.Procedure, func(stdio terminal.Stdio) error {
+		opts.AskOpts = prompt.WithStdio(stdio)
+		name, err = taskRunName(p, opts, nil)
+		if err != nil {
+			return err
+		}
+		if name != "tr-1" {
+			return errors.New("unexpected taskrun " + name)
+		}
+		return nil
+	})
+}
+
+func TestTaskRunDescribe_step_details(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	now := clock.Now()
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("tr-1", "ns",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("t1")),
+			tb.TaskRunStatus(
+				tb.PodName("tr-1-pod-2"),
+				tb.TaskRunStartTime(now.Add(-5*time.Minute)),
+				cb.TaskRunCompletionTime(now.Add(-2*time.Minute)),
+				tb.StatusCondition(apis.Condition{
+					Status: corev1.ConditionFalse,
+					Reason: resources.ReasonFailed,
+				}),
+				tb.StepState(
+					cb.StepName("build"),
+					tb.SetStepStateTerminated(corev1.ContainerStateTerminated{
+						Reason:     "Completed",
+						StartedAt:  metav1.NewTime(now.Add(-5 * time.Minute)),
+						FinishedAt: metav1.NewTime(now.Add(-4 * time.Minute)),
+					}),
+				),
+				tb.StepState(
+					cb.StepName("test"),
+					tb.SetStepStateTerminated(corev1.ContainerStateTerminated{
+						Reason:     "OOMKilled",
+						ExitCode:   137,
+						StartedAt:  metav1.NewTime(now.Add(-4 * time.Minute)),
+						FinishedAt: metav1.NewTime(now.Add(-2 * time.Minute)),
+					}),
+				),
+				func(s *v1alpha1.TaskRunStatus) {
+					s.Steps[0].ContainerName = "step-build"
+					s.Steps[0].ImageID = "docker-pullable://golang@sha256:1234"
+					s.Sidecars = []v1alpha1.SidecarState{
+						{Name: "docker", ImageID: "docker-pullable://docker@sha256:5678"},
+					}
+					s.RetriesStatus = []v1alpha1.TaskRunStatus{
+						{
+							PodName:        "tr-1-pod-1",
+							StartTime:      &metav1.Time{Time: now.Add(-10 * time.Minute)},
+							CompletionTime: &metav1.Time{Time: now.Add(-9 * time.Minute)},
+							Status: duckv1beta1.Status{
+								Conditions: duckv1beta1.Conditions{
+									{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: resources.ReasonFailed},
+								},
+							},
+						},
+					}
+				},
+			),
+		),
+	}
+
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr-1-pod-2", Namespace: "ns"},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+				Containers: []corev1.Container{
+					{Name: "step-build", Image: "golang:1.13"},
+					{Name: "step-test", Image: "golang:1.13"},
+					{Name: "sidecar-docker", Image: "docker:dind"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "sidecar-docker", State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"},
+					}},
+				},
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		TaskRuns: trs,
+		Pods:     pods,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clock, Kube: cs.Kube}
+
+	actual, err := test.ExecuteCommand(Command(p), "desc", "tr-1", "-n", "ns", "--events=false")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:        tr-1
+Namespace:   ns
+Task Ref:    t1
+Pod:    tr-1-pod-2
+Node:   node-1
+
+Status
+STARTED         DURATION    STATUS
+5 minutes ago   3 minutes   Failed
+
+Input Resources
+No resources
+
+Output Resources
+No resources
+
+Params
+No params
+
+Steps
+NAME    STATUS      EXIT CODE   STARTED         FINISHED        DURATION
+build   Completed   0           5 minutes ago   4 minutes ago   1 minute
+test    OOMKilled   137         4 minutes ago   2 minutes ago   2 minutes
+
+Step Images
+NAME    IMAGE         IMAGE ID
+build   golang:1.13   docker-pullable://golang@sha256:1234
+test    golang:1.13   ---
+
+Sidecars
+NAME     STATUS      IMAGE         IMAGE ID
+docker   Completed   docker:dind   docker-pullable://docker@sha256:5678
+
+Retries
+ATTEMPT   POD          STARTED          DURATION   STATUS
+1         tr-1-pod-1   10 minutes ago   1 minute   Failed
 `
+	test.AssertOutput(t, expected, actual)
+}
 
+func TestTaskRunDescribe_results(t *testing.T) {
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("tr-1", "ns",
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("build"),
+				tb.TaskRunOutputs(tb.TaskRunOutputsResource("image", tb.TaskResourceBindingRef("app-image"))),
+			),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Status: corev1.ConditionTrue,
+					Reason: resources.ReasonSucceeded,
+				}),
+				func(s *v1alpha1.TaskRunStatus) {
+					s.ResourcesResult = []v1alpha1.PipelineResourceResult{
+						{Key: "digest", Value: "sha256:1234", ResourceRef: v1alpha1.PipelineResourceRef{Name: "app-image"}},
+					}
+				},
+			),
+		),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{
+		TaskRuns: trs,
+		Namespaces: []*corev1.Namespace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ns",
+				},
+			},
+		},
+	})
+
+	p := &test.Params{Tekton: cs.Pipeline, Clock: clockwork.NewFakeClock(), Kube: cs.Kube}
+
+	actual, err := test.ExecuteCommand(Command(p), "desc", "tr-1", "-n", "ns", "--events=false")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := `Name:        tr-1
+Namespace:   ns
+Task Ref:    build
+
+Status
+STARTED    DURATION    STATUS
+---        ---         Succeeded
+
+Input Resources
+No resources
+
+Output Resources
+NAME    RESOURCE REF
+image   app-image
+
+Results
+RESOURCE    KEY      VALUE
+app-image   digest   sha256:1234
+
+Params
+No params
+
+Steps
+No steps
+`
 	test.AssertOutput(t, expected, actual)
 }
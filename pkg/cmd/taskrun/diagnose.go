@@ -0,0 +1,112 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskrun
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/diagnose"
+	validate "github.com/tektoncd/cli/pkg/helper/validate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func diagnoseCommand(p cli.Params) *cobra.Command {
+	eg := `Find why the TaskRun named 'foo' from namespace 'bar' is stuck or has failed:
+
+    tkn taskrun diagnose foo -n bar
+`
+
+	c := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Diagnose why a taskrun is stuck or has failed",
+		Long: `Diagnose why a taskrun is stuck or has failed.
+
+The pod of the taskrun, the state of its containers, the objects it needs and
+the warning events of the namespace are checked for images which cannot be
+pulled, pods which cannot be scheduled, unbound persistent volume claims,
+missing secrets and service accounts, steps killed as they ran out of memory
+and pods rejected by a resource quota.`,
+		Example:      eg,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &cli.Stream{
+				Out: cmd.OutOrStdout(),
+				Err: cmd.OutOrStderr(),
+			}
+
+			if err := validate.NamespaceExists(p); err != nil {
+				return err
+			}
+
+			return diagnoseTaskRun(p, s, args[0])
+		},
+	}
+
+	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_taskrun")
+	return c
+}
+
+func diagnoseTaskRun(p cli.Params, s *cli.Stream, trName string) error {
+	cs, err := p.Clients()
+	if err != nil {
+		return fmt.Errorf("failed to create tekton client")
+	}
+
+	tr, err := cs.Tekton.TektonV1alpha1().TaskRuns(p.Namespace()).Get(trName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find taskrun: %s", trName)
+	}
+
+	problems, err := diagnose.TaskRun(cs.Kube, tr)
+	if err != nil {
+		return fmt.Errorf("failed to diagnose taskrun %s: %s", trName, err)
+	}
+
+	pod := tr.Status.PodName
+	if pod == "" {
+		pod = "---"
+	}
+
+	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Name:\t%s\n", tr.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", tr.Namespace)
+	fmt.Fprintf(w, "Status:\t%s\n", formatted.Condition(tr.Status.Conditions))
+	fmt.Fprintf(w, "Pod:\t%s\n", pod)
+	if msg := hasFailed(tr); msg != "" {
+		fmt.Fprintf(w, "Message:\t%s\n", msg)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(s.Out, "\nProblems")
+	if len(problems) == 0 {
+		fmt.Fprintln(s.Out, "No problems found")
+		return nil
+	}
+
+	for _, pb := range problems {
+		fmt.Fprintf(s.Out, "%s\n  Fix: %s\n", pb, pb.Fix)
+	}
+	return nil
+}
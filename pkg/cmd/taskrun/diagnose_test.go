@@ -0,0 +1,144 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskRunDiagnose(t *testing.T) {
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("build-1", "ns",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build")),
+			tb.TaskRunStatus(
+				tb.PodName("build-1-pod"),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: "Pending",
+				}),
+			),
+		),
+		tb.TaskRun("build-2", "ns",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build")),
+			tb.TaskRunStatus(
+				tb.PodName("build-2-pod"),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+					Reason: "Succeeded",
+				}),
+			),
+		),
+	}
+
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-1-pod", Namespace: "ns"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "step-build", Image: "golang:nope"}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "step-build", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					}}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-2-pod", Namespace: "ns"},
+		},
+	}
+
+	ns := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ns",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: pods, Namespaces: ns})
+
+	testParams := []struct {
+		name      string
+		command   []string
+		wantError bool
+		want      string
+	}{
+		{
+			name:    "Image cannot be pulled",
+			command: []string{"diagnose", "build-1", "-n", "ns"},
+			want: `Name:        build-1
+Namespace:   ns
+Status:      Running(Pending)
+Pod:         build-1-pod
+
+Problems
+ImagePullBackOff: step build cannot pull image "golang:nope": Back-off pulling image
+  Fix: check the name and the tag of the image, and that the service account of the TaskRun has a pull secret for its registry
+`,
+		},
+		{
+			name:    "No problems",
+			command: []string{"diagnose", "build-2", "-n", "ns"},
+			want: `Name:        build-2
+Namespace:   ns
+Status:      Succeeded
+Pod:         build-2-pod
+
+Problems
+No problems found
+`,
+		},
+		{
+			name:      "Not found taskrun",
+			command:   []string{"diagnose", "nonexistent", "-n", "ns"},
+			wantError: true,
+			want:      "failed to find taskrun: nonexistent",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			p := &test.Params{Tekton: cs.Pipeline, Kube: cs.Kube}
+			taskrun := Command(p)
+
+			out, err := test.ExecuteCommand(taskrun, tp.command...)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out)
+		})
+	}
+}
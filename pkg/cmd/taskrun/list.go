@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	trhsort "github.com/tektoncd/cli/pkg/helper/taskrun/sort"
 	validate "github.com/tektoncd/cli/pkg/helper/validate"
 	"github.com/tektoncd/cli/pkg/printer"
@@ -37,7 +38,8 @@ const (
 )
 
 type ListOptions struct {
-	Limit int
+	Limit     int
+	StartedBy string
 }
 
 func listCommand(p cli.Params) *cobra.Command {
@@ -51,6 +53,10 @@ func listCommand(p cli.Params) *cobra.Command {
 List all TaskRuns of Task 'foo' in namespace 'bar':
 
     tkn taskrun list foo -n bar
+
+List all TaskRuns started by user 'jane' in namespace 'bar':
+
+    tkn tr list --started-by jane -n bar
 `
 
 	c := &cobra.Command{
@@ -77,7 +83,7 @@ List all TaskRuns of Task 'foo' in namespace 'bar':
 				return nil
 			}
 
-			trs, err := list(p, task, opts.Limit)
+			trs, err := list(p, task, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to list taskruns from %s namespace \n", p.Namespace())
 				return err
@@ -113,11 +119,12 @@ List all TaskRuns of Task 'foo' in namespace 'bar':
 
 	f.AddFlags(c)
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 0, "limit taskruns listed (default: return all taskruns)")
+	c.Flags().StringVar(&opts.StartedBy, "started-by", "", "show only the taskruns started by the given kubeconfig or local user")
 
 	return c
 }
 
-func list(p cli.Params, task string, limit int) (*v1alpha1.TaskRunList, error) {
+func list(p cli.Params, task string, opts *ListOptions) (*v1alpha1.TaskRunList, error) {
 	cs, err := p.Clients()
 	if err != nil {
 		return nil, err
@@ -136,6 +143,17 @@ func list(p cli.Params, task string, limit int) (*v1alpha1.TaskRunList, error) {
 		return nil, err
 	}
 
+	if opts.StartedBy != "" {
+		filtered := trs.Items[:0]
+		for _, tr := range trs.Items {
+			if invoker.IsStartedBy(tr.ObjectMeta, opts.StartedBy) {
+				filtered = append(filtered, tr)
+			}
+		}
+		trs.Items = filtered
+	}
+
+	limit := opts.Limit
 	trslen := len(trs.Items)
 
 	if trslen != 0 {
@@ -170,15 +188,23 @@ func printFormatted(s *cli.Stream, trs *v1alpha1.TaskRunList, c clockwork.Clock)
 	}
 
 	w := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	fmt.Fprintln(w, "NAME\tSTARTED\tDURATION\tSTATUS\t")
+	fmt.Fprintln(w, "NAME\tSTARTED\tDURATION\tSTATUS\tSTARTED BY\t")
 	for _, tr := range trs.Items {
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n",
 			tr.Name,
 			formatted.Age(tr.Status.StartTime, c),
 			formatted.Duration(tr.Status.StartTime, tr.Status.CompletionTime),
 			formatted.Condition(tr.Status.Conditions),
+			startedBy(tr.ObjectMeta),
 		)
 	}
 	return w.Flush()
 }
+
+func startedBy(meta v1.ObjectMeta) string {
+	if u := invoker.StartedBy(meta); u != "" {
+		return u
+	}
+	return "---"
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/jonboulle/clockwork"
 	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/helper/invoker"
 	"github.com/tektoncd/cli/pkg/test"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
@@ -49,6 +50,7 @@ func TestListTaskRuns(t *testing.T) {
 			),
 		),
 		tb.TaskRun("tr1-1", "foo",
+			tb.TaskRunAnnotation(invoker.KubeUserAnnotation, "jane"),
 			tb.TaskRunLabel("tekton.dev/task", "bar"),
 			tb.TaskRunSpec(tb.TaskRunTaskRef("bar")),
 			tb.TaskRunStatus(
@@ -120,8 +122,19 @@ func TestListTaskRuns(t *testing.T) {
 			command: command(t, trs, now, ns),
 			args:    []string{"list", "bar", "-n", "foo"},
 			expected: []string{
-				"NAME    STARTED      DURATION   STATUS      ",
-				"tr1-1   1 hour ago   1 minute   Succeeded   ",
+				"NAME    STARTED      DURATION   STATUS      STARTED BY   ",
+				"tr1-1   1 hour ago   1 minute   Succeeded   jane         ",
+				"",
+			},
+			wantError: false,
+		},
+		{
+			name:    "by user who started",
+			command: command(t, trs, now, ns),
+			args:    []string{"list", "--started-by", "jane", "-n", "foo"},
+			expected: []string{
+				"NAME    STARTED      DURATION   STATUS      STARTED BY   ",
+				"tr1-1   1 hour ago   1 minute   Succeeded   jane         ",
 				"",
 			},
 			wantError: false,
@@ -131,12 +144,12 @@ func TestListTaskRuns(t *testing.T) {
 			command: command(t, trs, now, ns),
 			args:    []string{"list", "-n", "foo"},
 			expected: []string{
-				"NAME    STARTED          DURATION   STATUS      ",
-				"tr0-1   ---              ---        Succeeded   ",
-				"tr3-1   ---              ---        Failed      ",
-				"tr2-2   59 minutes ago   1 minute   Failed      ",
-				"tr1-1   1 hour ago       1 minute   Succeeded   ",
-				"tr2-1   1 hour ago       ---        Running     ",
+				"NAME    STARTED          DURATION   STATUS      STARTED BY   ",
+				"tr0-1   ---              ---        Succeeded   ---          ",
+				"tr3-1   ---              ---        Failed      ---          ",
+				"tr2-2   59 minutes ago   1 minute   Failed      ---          ",
+				"tr1-1   1 hour ago       1 minute   Succeeded   jane         ",
+				"tr2-1   1 hour ago       ---        Running     ---          ",
 				"",
 			},
 			wantError: false,
@@ -166,8 +179,8 @@ func TestListTaskRuns(t *testing.T) {
 			command: command(t, trs, now, ns),
 			args:    []string{"list", "-n", "foo", "--limit", fmt.Sprintf("%d", 1)},
 			expected: []string{
-				"NAME    STARTED   DURATION   STATUS      ",
-				"tr0-1   ---       ---        Succeeded   ",
+				"NAME    STARTED   DURATION   STATUS      STARTED BY   ",
+				"tr0-1   ---       ---        Succeeded   ---          ",
 				"",
 			},
 			wantError: false,
@@ -186,12 +199,12 @@ func TestListTaskRuns(t *testing.T) {
 			command: command(t, trs, now, ns),
 			args:    []string{"list", "-n", "foo", "--limit", fmt.Sprintf("%d", 7)},
 			expected: []string{
-				"NAME    STARTED          DURATION   STATUS      ",
-				"tr0-1   ---              ---        Succeeded   ",
-				"tr3-1   ---              ---        Failed      ",
-				"tr2-2   59 minutes ago   1 minute   Failed      ",
-				"tr1-1   1 hour ago       1 minute   Succeeded   ",
-				"tr2-1   1 hour ago       ---        Running     ",
+				"NAME    STARTED          DURATION   STATUS      STARTED BY   ",
+				"tr0-1   ---              ---        Succeeded   ---          ",
+				"tr3-1   ---              ---        Failed      ---          ",
+				"tr2-2   59 minutes ago   1 minute   Failed      ---          ",
+				"tr1-1   1 hour ago       1 minute   Succeeded   jane         ",
+				"tr2-1   1 hour ago       ---        Running     ---          ",
 				"",
 			},
 			wantError: false,
@@ -259,8 +272,8 @@ func TestListTaskRuns_no_condition(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	expected := `NAME    STARTED      DURATION   STATUS   
-tr1-1   1 hour ago   1 minute   ---      
+	expected := `NAME    STARTED      DURATION   STATUS   STARTED BY   
+tr1-1   1 hour ago   1 minute   ---      ---          
 `
 
 	test.AssertOutput(t, expected, got)
@@ -15,36 +15,70 @@
 package taskrun
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/helper/diagnose"
+	"github.com/tektoncd/cli/pkg/helper/options"
 	"github.com/tektoncd/cli/pkg/helper/pods"
 	"github.com/tektoncd/cli/pkg/helper/pods/stream"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"knative.dev/pkg/apis/duck/v1beta1"
 )
 
+const sidecarPrefix = "sidecar-"
+
 type step struct {
 	name      string
 	container string
 	state     corev1.ContainerState
+	sidecar   bool
+	attempt   int
 }
 
 func (s *step) hasStarted() bool {
 	return s.state.Waiting == nil
 }
 
+// LogType tells whether a Log is a line written by a step or marks the
+// start or the end of the step
+type LogType string
+
+const (
+	// LogLine is a line written by a step
+	LogLine LogType = "log"
+	// StepStart is sent before the first line of a step
+	StepStart LogType = "step-start"
+	// StepEnd is sent after the last line of a step, with its exit code
+	// when the step has terminated
+	StepEnd LogType = "step-end"
+	// ConditionResult tells whether the check of a condition passed, it
+	// is sent before the end of the step checking the condition
+	ConditionResult LogType = "condition"
+)
+
 //Log data to write on log channel
 type Log struct {
-	Task string
-	Step string
-	Log  string
+	Type      LogType
+	Task      string
+	TaskRun   string
+	Step      string
+	Container string
+	Pod       string
+	Timestamp time.Time
+	Log       string
+	ExitCode  *int32
+	// Attempt is the number of the attempt of the TaskRun, starting at 1,
+	// when the logs of its retries are requested
+	Attempt int
 }
 
 type LogReader struct {
@@ -57,9 +91,23 @@ type LogReader struct {
 	Follow   bool
 	AllSteps bool
 	Stream   *cli.Stream
+	// Steps restricts the logs to the steps with these names
+	Steps []string
+	// Sidecars adds the logs of the sidecars after the ones of the steps
+	Sidecars bool
+	// Attempt selects the attempt of the TaskRun whose logs are read,
+	// the latest one when 0 or all of them with AllAttempts
+	Attempt int
+	// Condition is the name of the condition the TaskRun checks, its steps
+	// are then labelled condition/<name>
+	Condition string
+	// LogOptions restricts the logs read from each step
+	LogOptions pods.LogOptions
 }
 
-func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
+// Read streams the logs of the TaskRun until they are all read or ctx is
+// done, the channels being closed in both cases
+func (lr *LogReader) Read(ctx context.Context) (<-chan Log, <-chan error, error) {
 	tkn := lr.Clients.Tekton
 	tr, err := tkn.TektonV1alpha1().TaskRuns(lr.Ns).Get(lr.Run, metav1.GetOptions{})
 	if err != nil {
@@ -68,14 +116,14 @@ func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
 
 	lr.formTaskName(tr)
 
-	return lr.readLogs(tr)
+	return lr.readLogs(ctx, tr)
 }
 
-func (lr *LogReader) readLogs(tr *v1alpha1.TaskRun) (<-chan Log, <-chan error, error) {
+func (lr *LogReader) readLogs(ctx context.Context, tr *v1alpha1.TaskRun) (<-chan Log, <-chan error, error) {
 	if lr.Follow {
-		return lr.readLiveLogs()
+		return lr.readLiveLogs(ctx)
 	}
-	return lr.readAvailableLogs(tr)
+	return lr.readAvailableLogs(ctx, tr)
 }
 
 func (lr *LogReader) formTaskName(tr *v1alpha1.TaskRun) {
@@ -96,118 +144,325 @@ func (lr *LogReader) formTaskName(tr *v1alpha1.TaskRun) {
 	lr.Task = fmt.Sprintf("Task %d", lr.Number)
 }
 
-func (lr *LogReader) readLiveLogs() (<-chan Log, <-chan error, error) {
-	tr, err := lr.waitUntilPodNameAvailable(10)
+func (lr *LogReader) readLiveLogs(ctx context.Context) (<-chan Log, <-chan error, error) {
+	tr, err := lr.waitUntilPodNameAvailable(ctx, 10)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, current, err := lr.attempts(tr)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var (
-		podName = tr.Status.PodName
-		kube    = lr.Clients.Kube
+		p     *pods.Pod
+		steps []*step
 	)
 
-	p := pods.New(podName, lr.Ns, kube, lr.Streamer)
-	pod, err := p.Wait()
-	if err != nil {
-		return nil, nil, errors.New(fmt.Sprintf("task %s failed: %s. Run tkn tr desc %s for more details.", lr.Task, strings.TrimSpace(err.Error()), tr.Name))
+	if current != nil {
+		p = pods.New(current.pod, lr.Ns, lr.Clients.Kube, lr.Streamer)
+		p.Pending = lr.showHints()
+		pod, err := p.Wait(ctx)
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			return nil, nil, err
+		}
+		if err != nil {
+			return nil, nil, errors.New(fmt.Sprintf("task %s failed: %s. Run tkn tr desc %s for more details.", lr.Task, strings.TrimSpace(err.Error()), tr.Name))
+		}
+		steps = lr.filterSteps(pod, tr.Status, current.number)
 	}
 
-	steps := filterSteps(pod, lr.AllSteps)
-	logC, errC := lr.readStepsLogs(steps, p, lr.Follow)
-	return logC, errC, err
+	logC, errC := lr.readAttemptsLogs(ctx, previous, steps, p, lr.Follow)
+	return logC, errC, nil
 }
 
-func (lr *LogReader) readAvailableLogs(tr *v1alpha1.TaskRun) (<-chan Log, <-chan error, error) {
+func (lr *LogReader) readAvailableLogs(ctx context.Context, tr *v1alpha1.TaskRun) (<-chan Log, <-chan error, error) {
 	if !tr.HasStarted() {
 		return nil, nil, fmt.Errorf("task %s has not started yet", lr.Task)
 	}
 
-	//Check if taskrun failed on start up
-	if err := hasTaskRunFailed(tr.Status.Conditions, lr.Task); err != nil {
+	previous, current, err := lr.attempts(tr)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	if tr.Status.PodName == "" {
-		return nil, nil, fmt.Errorf("pod for taskrun %s not available yet", tr.Name)
-	}
-
 	var (
-		kube    = lr.Clients.Kube
-		podName = tr.Status.PodName
+		p     *pods.Pod
+		steps []*step
 	)
 
-	p := pods.New(podName, lr.Ns, kube, lr.Streamer)
-	pod, err := p.Get()
-	if err != nil {
-		return nil, nil, errors.New(fmt.Sprintf("task %s failed: %s. Run tkn tr desc %s for more details.", lr.Task, strings.TrimSpace(err.Error()), tr.Name))
+	if current != nil {
+		//Check if taskrun failed on start up, a failed condition check
+		//has logs telling why the condition is not met
+		if lr.Condition == "" || tr.Status.PodName == "" {
+			if err := hasTaskRunFailed(tr.Status.Conditions, lr.Task); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if tr.Status.PodName == "" {
+			return nil, nil, fmt.Errorf("pod for taskrun %s not available yet", tr.Name)
+		}
+
+		p = pods.New(current.pod, lr.Ns, lr.Clients.Kube, lr.Streamer)
+		pod, err := p.Get()
+		if k8serrors.IsNotFound(err) {
+			return nil, nil, lr.podDeletedError(*current)
+		}
+		if err != nil {
+			return nil, nil, errors.New(fmt.Sprintf("task %s failed: %s. Run tkn tr desc %s for more details.", lr.Task, strings.TrimSpace(err.Error()), tr.Name))
+		}
+		steps = lr.filterSteps(pod, tr.Status, current.number)
 	}
 
-	steps := filterSteps(pod, lr.AllSteps)
-	logC, errC := lr.readStepsLogs(steps, p, lr.Follow)
+	logC, errC := lr.readAttemptsLogs(ctx, previous, steps, p, lr.Follow)
 	return logC, errC, nil
 }
 
-func (lr *LogReader) readStepsLogs(steps []*step, pod *pods.Pod, follow bool) (<-chan Log, <-chan error) {
+// readAttemptsLogs reads the logs of the previous attempts as they are
+// available, then the ones of the steps of the current attempt. Reading
+// stops as soon as ctx is done.
+func (lr *LogReader) readAttemptsLogs(ctx context.Context, previous []attempt, steps []*step, pod *pods.Pod, follow bool) (<-chan Log, <-chan error) {
 	logC := make(chan Log)
 	errC := make(chan error)
+	out := &logSender{ctx: ctx, logC: logC, errC: errC}
 
 	go func() {
 		defer close(logC)
 		defer close(errC)
 
-		for _, step := range steps {
-			if !follow && !step.hasStarted() {
+		for _, a := range previous {
+			p := pods.New(a.pod, lr.Ns, lr.Clients.Kube, lr.Streamer)
+			ap, err := p.Get()
+			if k8serrors.IsNotFound(err) {
+				if !out.error(lr.podDeletedError(a)) {
+					return
+				}
 				continue
 			}
-
-			container := pod.Container(step.container)
-			podC, perrC, err := container.LogReader(follow).Read()
 			if err != nil {
-				errC <- fmt.Errorf("error in getting logs for step %s: %s", step.name, err)
+				if !out.error(fmt.Errorf("failed to get pod %s of attempt %d: %s", a.pod, a.number, err)) {
+					return
+				}
 				continue
 			}
 
-			for podC != nil || perrC != nil {
-				select {
-				case l, ok := <-podC:
-					if !ok {
-						podC = nil
-						logC <- Log{Task: lr.Task, Step: step.name, Log: "EOFLOG"}
-						continue
-					}
-					logC <- Log{Task: lr.Task, Step: step.name, Log: l.Log}
+			if !lr.pipeStepsLogs(out, lr.filterSteps(ap, a.status, a.number), p, false) {
+				return
+			}
+		}
+
+		if pod != nil {
+			lr.pipeStepsLogs(out, steps, pod, follow)
+		}
+	}()
+
+	return logC, errC
+}
 
-				case e, ok := <-perrC:
-					if !ok {
-						perrC = nil
-						continue
+// logSender sends the logs and the errors read until its context is done
+type logSender struct {
+	ctx  context.Context
+	logC chan<- Log
+	errC chan<- error
+}
+
+// log sends l, it returns false when the context is done
+func (s *logSender) log(l Log) bool {
+	select {
+	case s.logC <- l:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// error sends err, it returns false when the context is done
+func (s *logSender) error(err error) bool {
+	select {
+	case s.errC <- err:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// pipeStepsLogs sends the logs of the steps, it returns false when the
+// context is done
+func (lr *LogReader) pipeStepsLogs(out *logSender, steps []*step, pod *pods.Pod, follow bool) bool {
+	for _, step := range steps {
+		if !follow && !step.hasStarted() {
+			continue
+		}
+
+		container := pod.Container(step.container)
+		podC, perrC, err := container.LogReader(follow, lr.LogOptions).Read(out.ctx)
+		if err != nil {
+			if !out.error(fmt.Errorf("error in getting logs for step %s: %s", step.name, err)) {
+				return false
+			}
+			continue
+		}
+
+		if !out.log(lr.stepLog(StepStart, step, pod.Name)) {
+			return false
+		}
+
+		for podC != nil || perrC != nil {
+			select {
+			case l, ok := <-podC:
+				if !ok {
+					podC = nil
+					if out.ctx.Err() != nil {
+						return false
+					}
+					end := lr.stepEnd(step, container, pod.Name)
+					if lr.Condition != "" && end.ExitCode != nil {
+						if !out.log(lr.conditionResult(step, pod.Name, *end.ExitCode)) {
+							return false
+						}
+					}
+					if !out.log(end) {
+						return false
 					}
+					continue
+				}
+				log := lr.stepLog(LogLine, step, pod.Name)
+				log.Timestamp = l.Timestamp
+				log.Log = l.Log
+				if !out.log(log) {
+					return false
+				}
 
-					errC <- fmt.Errorf("failed to get logs for %s: %s", step.name, e)
+			case e, ok := <-perrC:
+				if !ok {
+					perrC = nil
+					continue
 				}
-			}
 
-			if err := container.Status(); err != nil {
-				errC <- err
-				return
+				if !out.error(fmt.Errorf("failed to get logs for %s: %s", step.name, e)) {
+					return false
+				}
 			}
 		}
-	}()
 
-	return logC, errC
+		if lr.Condition != "" {
+			continue
+		}
+
+		// the steps following a failed step don't run
+		if err := container.Status(); err != nil {
+			return out.error(err)
+		}
+	}
+
+	return true
+}
+
+func (lr *LogReader) stepLog(t LogType, step *step, pod string) Log {
+	log := Log{
+		Type:      t,
+		Task:      lr.Task,
+		TaskRun:   lr.Run,
+		Step:      step.name,
+		Container: step.container,
+		Pod:       pod,
+		Attempt:   step.attempt,
+	}
+
+	if lr.Condition != "" {
+		log.Step = "condition/" + lr.Condition
+	}
+
+	if t == StepStart {
+		if step.state.Running != nil {
+			log.Timestamp = step.state.Running.StartedAt.Time
+		} else if step.state.Terminated != nil {
+			log.Timestamp = step.state.Terminated.StartedAt.Time
+		}
+	}
+
+	return log
+}
+
+// stepEnd marks the end of the logs of the step with its exit code, which
+// is only known once the container has terminated
+func (lr *LogReader) stepEnd(step *step, container *pods.Container, pod string) Log {
+	log := lr.stepLog(StepEnd, step, pod)
+
+	terminated, err := container.Terminated()
+	if err != nil || terminated == nil {
+		return log
+	}
+
+	exitCode := terminated.ExitCode
+	log.ExitCode = &exitCode
+	log.Timestamp = terminated.FinishedAt.Time
+	return log
 }
 
-func filterSteps(pod *corev1.Pod, allSteps bool) []*step {
+func (lr *LogReader) conditionResult(step *step, pod string, exitCode int32) Log {
+	log := lr.stepLog(ConditionResult, step, pod)
+	log.ExitCode = &exitCode
+	log.Log = "passed"
+	if exitCode != 0 {
+		log.Log = "failed"
+	}
+	return log
+}
+
+func (lr *LogReader) filterSteps(pod *corev1.Pod, status v1alpha1.TaskRunStatus, attempt int) []*step {
 	steps := []*step{}
 
-	if allSteps {
+	if lr.AllSteps {
 		steps = append(steps, getInitSteps(pod)...)
 	}
 
-	steps = append(steps, getSteps(pod)...)
+	sidecars := []*step{}
+	for _, s := range getSteps(pod, sidecarContainers(status)) {
+		if s.sidecar {
+			sidecars = append(sidecars, s)
+			continue
+		}
+		steps = append(steps, s)
+	}
 
-	return steps
+	if lr.Sidecars {
+		steps = append(steps, sidecars...)
+	}
+
+	for _, s := range steps {
+		s.attempt = attempt
+	}
+
+	if len(lr.Steps) == 0 {
+		return steps
+	}
+
+	selected := map[string]bool{}
+	for _, name := range lr.Steps {
+		selected[name] = true
+	}
+
+	filtered := []*step{}
+	for _, s := range steps {
+		if selected[s.name] || (s.sidecar && selected[strings.TrimPrefix(s.name, sidecarPrefix)]) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// sidecarContainers returns the names of the containers running the
+// sidecars reported in the status of the taskrun
+func sidecarContainers(status v1alpha1.TaskRunStatus) map[string]bool {
+	containers := map[string]bool{}
+	for _, s := range status.Sidecars {
+		containers[s.Name] = true
+		containers[sidecarPrefix+s.Name] = true
+	}
+	return containers
 }
 
 func getInitSteps(pod *corev1.Pod) []*step {
@@ -228,7 +483,7 @@ func getInitSteps(pod *corev1.Pod) []*step {
 	return steps
 }
 
-func getSteps(pod *corev1.Pod) []*step {
+func getSteps(pod *corev1.Pod, sidecars map[string]bool) []*step {
 	status := map[string]corev1.ContainerState{}
 	for _, cs := range pod.Status.ContainerStatuses {
 		status[cs.Name] = cs.State
@@ -236,21 +491,75 @@ func getSteps(pod *corev1.Pod) []*step {
 
 	steps := []*step{}
 	for _, c := range pod.Spec.Containers {
-		steps = append(steps, &step{
+		s := &step{
 			name:      strings.TrimPrefix(c.Name, "step-"),
 			container: c.Name,
 			state:     status[c.Name],
-		})
+		}
+
+		if sidecars[c.Name] || strings.HasPrefix(c.Name, sidecarPrefix) {
+			s.name = sidecarPrefix + strings.TrimPrefix(c.Name, sidecarPrefix)
+			s.sidecar = true
+		}
+
+		steps = append(steps, s)
 	}
 
 	return steps
 }
 
+// AllAttempts reads the logs of all the attempts of a TaskRun with retries
+const AllAttempts = options.AllAttempts
+
+// attempt is one execution of a TaskRun, the status of the previous
+// attempts are kept in the RetriesStatus of the TaskRun
+type attempt struct {
+	number int
+	pod    string
+	status v1alpha1.TaskRunStatus
+}
+
+// attempts returns the previous attempts whose logs are read apart from
+// the current attempt, which is nil when it isn't selected. Attempts are
+// only numbered when they are requested.
+func (lr *LogReader) attempts(tr *v1alpha1.TaskRun) ([]attempt, *attempt, error) {
+	if lr.Attempt == 0 {
+		return nil, &attempt{pod: tr.Status.PodName, status: tr.Status}, nil
+	}
+
+	all := []attempt{}
+	for i, rs := range tr.Status.RetriesStatus {
+		all = append(all, attempt{number: i + 1, pod: rs.PodName, status: rs})
+	}
+	current := attempt{number: len(all) + 1, pod: tr.Status.PodName, status: tr.Status}
+
+	if lr.Attempt == AllAttempts {
+		return all, &current, nil
+	}
+
+	if lr.Attempt == current.number {
+		return nil, &current, nil
+	}
+
+	if lr.Attempt < 0 || lr.Attempt > current.number {
+		return nil, nil, fmt.Errorf("task %s has %d attempts, attempt %d does not exist", lr.Task, current.number, lr.Attempt)
+	}
+
+	return []attempt{all[lr.Attempt-1]}, nil, nil
+}
+
+func (lr *LogReader) podDeletedError(a attempt) error {
+	if a.number == 0 {
+		return fmt.Errorf("pod %s of task %s has been deleted, its logs are no longer available", a.pod, lr.Task)
+	}
+	return fmt.Errorf("pod %s of attempt %d of task %s has been deleted, its logs are no longer available", a.pod, a.number, lr.Task)
+}
+
 // Reading of logs should wait until the name of the pod is
 // updated in the status. Open a watch channel on the task run
 // and keep checking the status until the pod name updates
 // or the timeout is reached.
-func (lr *LogReader) waitUntilPodNameAvailable(timeout time.Duration) (*v1alpha1.TaskRun, error) {
+func (lr *LogReader) waitUntilPodNameAvailable(ctx context.Context, timeout time.Duration) (*v1alpha1.TaskRun, error) {
 	var first = true
 	opts := metav1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector("metadata.name", lr.Run).String(),
@@ -271,8 +580,11 @@ func (lr *LogReader) waitUntilPodNameAvailable(timeout time.Duration) (*v1alpha1
 	}
 	for {
 		select {
+		case <-ctx.Done():
+			watchRun.Stop()
+			return nil, ctx.Err()
 		case event := <-watchRun.ResultChan():
-			run := event.Object.(*v1alpha1.TaskRun)
+			run = event.Object.(*v1alpha1.TaskRun)
 			if run.Status.PodName != "" {
 				watchRun.Stop()
 				return run, nil
@@ -285,14 +597,54 @@ func (lr *LogReader) waitUntilPodNameAvailable(timeout time.Duration) (*v1alpha1
 
 			//Check if taskrun failed on start up
 			if err = hasTaskRunFailed(run.Status.Conditions, lr.Task); err != nil {
-				return nil, err
+				return nil, lr.withHints(err, run)
 			}
 
-			return nil, fmt.Errorf("task %s create has not started yet or pod for task not yet available", lr.Task)
+			return nil, lr.withHints(fmt.Errorf("task %s create has not started yet or pod for task not yet available", lr.Task), run)
 		}
 	}
 }
 
+// showHints returns a function reporting, once each, the problems keeping
+// the pod of the TaskRun from running while its logs are waited for
+func (lr *LogReader) showHints() func(*corev1.Pod) {
+	shown := map[string]bool{}
+
+	return func(pod *corev1.Pod) {
+		if lr.Stream == nil {
+			return
+		}
+
+		for _, p := range diagnose.Pod(pod) {
+			if shown[p.String()] {
+				continue
+			}
+			shown[p.String()] = true
+			fmt.Fprintf(lr.Stream.Err, "task %s is waiting: %s\n  hint: %s\n", lr.Task, p, p.Fix)
+		}
+	}
+}
+
+// withHints adds to err the problems found by diagnosing the TaskRun, which
+// is fetched again to be diagnosed as it is now rather than as it was last
+// seen
+func (lr *LogReader) withHints(err error, tr *v1alpha1.TaskRun) error {
+	if latest, gerr := lr.Clients.Tekton.TektonV1alpha1().TaskRuns(lr.Ns).Get(tr.Name, metav1.GetOptions{}); gerr == nil {
+		tr = latest
+	}
+
+	problems, derr := diagnose.TaskRun(lr.Clients.Kube, tr)
+	if derr != nil || len(problems) == 0 {
+		return err
+	}
+
+	msg := err.Error()
+	for _, p := range problems {
+		msg += fmt.Sprintf("\n%s\n  hint: %s", p, p.Fix)
+	}
+	return errors.New(msg)
+}
+
 func hasTaskRunFailed(trConditions v1beta1.Conditions, taskName string) error {
 	if len(trConditions) != 0 && trConditions[0].Status == corev1.ConditionFalse {
 		return fmt.Errorf("task %s has failed: %s", taskName, trConditions[0].Message)
@@ -0,0 +1,316 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/cli/pkg/helper/options"
+	"github.com/tektoncd/cli/pkg/helper/pods"
+	trsort "github.com/tektoncd/cli/pkg/helper/taskrun/sort"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SearchResult holds the lines of the logs of a run matching the searched
+// pattern
+type SearchResult struct {
+	Run       string
+	Status    string
+	StartTime *metav1.Time
+	Matches   []LogRecord
+	Errors    []string
+}
+
+// Match adds the record to the matches when it is a log line matching
+// pattern
+func (r *SearchResult) Match(pattern *regexp.Regexp, rec LogRecord) {
+	if rec.Type != string(LogLine) || !pattern.MatchString(rec.Message) {
+		return
+	}
+	r.Matches = append(r.Matches, rec)
+}
+
+// Scan matches the records read from recC and records the errors read from
+// errC, until both channels are closed
+func (r *SearchResult) Scan(pattern *regexp.Regexp, recC <-chan LogRecord, errC <-chan error) {
+	for recC != nil || errC != nil {
+		select {
+		case rec, ok := <-recC:
+			if !ok {
+				recC = nil
+				continue
+			}
+			r.Match(pattern, rec)
+		case err, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			r.AddError(err)
+		}
+	}
+}
+
+// Records sends the records returned by next until it reports the end of
+// the logs or ctx is done
+func Records(ctx context.Context, next func() (LogRecord, bool)) <-chan LogRecord {
+	recC := make(chan LogRecord)
+	go func() {
+		defer close(recC)
+		for {
+			rec, ok := next()
+			if !ok {
+				return
+			}
+			select {
+			case recC <- rec:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return recC
+}
+
+// AddError records an error met while reading the logs of the run
+func (r *SearchResult) AddError(err error) {
+	if msg := strings.TrimSpace(err.Error()); msg != "" {
+		r.Errors = append(r.Errors, msg)
+	}
+}
+
+// Search calls scan for each of the runs, at most parallel of them at a
+// time, and returns the results in the order of the runs. No more scan is
+// started once ctx is done, the results of the runs left are nil.
+func Search(ctx context.Context, runs []string, parallel int, scan func(run string) *SearchResult) []*SearchResult {
+	results := make([]*SearchResult, len(runs))
+	sem := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	for i, run := range runs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, run string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = scan(run)
+		}(i, run)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// SearchWriter prints the matching lines of each run, with the matches
+// highlighted, followed by the number of matches of each run
+type SearchWriter struct {
+	Pattern *regexp.Regexp
+	Clock   clockwork.Clock
+	// Interrupted tells that the search was interrupted, the results are
+	// then reported as partial rather than concluded
+	Interrupted bool
+
+	fmt *formatted.Color
+}
+
+// NewSearchWriter returns a SearchWriter highlighting pattern
+func NewSearchWriter(pattern *regexp.Regexp, clock clockwork.Clock) *SearchWriter {
+	return &SearchWriter{
+		Pattern: pattern,
+		Clock:   clock,
+		fmt:     formatted.NewColor(),
+	}
+}
+
+// Write prints the results, which are sorted from the newest run to the
+// oldest one, skipping the runs which were not searched
+func (w *SearchWriter) Write(s *cli.Stream, results []*SearchResult) error {
+	var first *SearchResult
+	searched := 0
+
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		searched++
+		if len(r.Matches) == 0 && len(r.Errors) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(s.Out, "%s (%s, started %s)\n", r.Run, r.Status, formatted.Age(r.StartTime, w.Clock))
+		for _, m := range r.Matches {
+			w.writeMatch(s, m)
+		}
+		for _, e := range r.Errors {
+			w.fmt.Error(s.Err, "%s\n", e)
+		}
+		fmt.Fprintln(s.Out)
+
+		if len(r.Matches) > 0 {
+			first = r
+		}
+	}
+
+	t := tabwriter.NewWriter(s.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(t, "RUN\tSTATUS\tSTARTED\tMATCHES\t")
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%d\t\n",
+			r.Run,
+			r.Status,
+			formatted.Age(r.StartTime, w.Clock),
+			len(r.Matches),
+		)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	if w.Interrupted {
+		return fmt.Errorf("search interrupted, the results of the %d runs searched out of %d are partial", searched, len(results))
+	}
+
+	if first == nil {
+		fmt.Fprintf(s.Out, "\nNo match for %q\n", w.Pattern)
+		return nil
+	}
+
+	fmt.Fprintf(s.Out, "\nFirst match in %s, started %s\n", first.Run, formatted.Age(first.StartTime, w.Clock))
+	return nil
+}
+
+func (w *SearchWriter) writeMatch(s *cli.Stream, m LogRecord) {
+	if m.Timestamp != nil {
+		fmt.Fprintf(s.Out, "%s ", formatted.Timestamp(*m.Timestamp))
+	}
+
+	label := m.Step
+	if m.Pipeline != "" || m.PipelineRun != "" {
+		label = m.Task + " : " + m.Step
+	}
+	if m.Attempt > 0 {
+		label = fmt.Sprintf("%s (attempt %d)", label, m.Attempt)
+	}
+	w.fmt.Rainbow.Fprintf(m.Step, s.Out, "[%s] ", label)
+
+	fmt.Fprintf(s.Out, "%s\n", w.fmt.Highlight(m.Message, w.Pattern.FindAllStringIndex(m.Message, -1)))
+}
+
+// SearchLogs looks for the lines matching opts.Grep in the logs of the
+// last opts.Runs runs of task opts.TaskName, until ctx is done
+func SearchLogs(ctx context.Context, opts *options.LogOptions) error {
+	pattern, err := opts.GrepPattern()
+	if err != nil {
+		return err
+	}
+
+	logOpts, err := opts.PodLogOptions()
+	if err != nil {
+		return err
+	}
+
+	cs, err := opts.Params.Clients()
+	if err != nil {
+		return err
+	}
+
+	ns := opts.Params.Namespace()
+	trs, err := cs.Tekton.TektonV1alpha1().TaskRuns(ns).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("tekton.dev/task=%s", opts.TaskName),
+	})
+	if err != nil {
+		return err
+	}
+
+	runs := map[string]*v1alpha1.TaskRun{}
+	names := []string{}
+	sorted := trsort.SortTaskRunsByStartTime(trs.Items)
+	for i, tr := range sorted {
+		if len(names) == opts.Runs {
+			break
+		}
+		if !opts.HasStatus(tr.Status.Conditions) {
+			continue
+		}
+		runs[tr.Name] = &sorted[i]
+		names = append(names, tr.Name)
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintln(opts.Stream.Err, "No taskruns found for task:", opts.TaskName)
+		return nil
+	}
+
+	streamer := opts.Streamer
+	if streamer == nil {
+		streamer = pods.NewStream
+	}
+
+	results := Search(ctx, names, opts.Parallel, func(name string) *SearchResult {
+		tr := runs[name]
+		r := &SearchResult{
+			Run:       name,
+			Status:    formatted.Condition(tr.Status.Conditions),
+			StartTime: tr.Status.StartTime,
+		}
+
+		lr := &LogReader{
+			Task:       opts.TaskName,
+			Run:        name,
+			Ns:         ns,
+			Clients:    cs,
+			Streamer:   streamer,
+			Stream:     opts.Stream,
+			AllSteps:   opts.AllSteps,
+			LogOptions: logOpts,
+		}
+
+		logC, errC, err := lr.Read(ctx)
+		if err != nil {
+			r.AddError(err)
+			return r
+		}
+
+		r.Scan(pattern, Records(ctx, func() (LogRecord, bool) {
+			l, ok := <-logC
+			return l.Record(), ok
+		}), errC)
+		return r
+	})
+
+	w := NewSearchWriter(pattern, opts.Params.Time())
+	w.Interrupted = ctx.Err() != nil
+	return w.Write(opts.Stream, results)
+}
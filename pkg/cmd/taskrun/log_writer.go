@@ -15,7 +15,10 @@
 package taskrun
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/formatted"
@@ -41,12 +44,26 @@ func (lw *LogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
 				continue
 			}
 
-			if l.Log == "EOFLOG" {
+			switch l.Type {
+			case StepStart:
+				continue
+			case StepEnd:
 				fmt.Fprintf(s.Out, "\n")
 				continue
+			case ConditionResult:
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s] ", l.Step)
+				fmt.Fprintf(s.Out, "condition check %s\n", l.Log)
+				continue
 			}
 
-			lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s] ", l.Step)
+			if !l.Timestamp.IsZero() {
+				fmt.Fprintf(s.Out, "%s ", formatted.Timestamp(l.Timestamp))
+			}
+			if l.Attempt > 0 {
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s (attempt %d)] ", l.Step, l.Attempt)
+			} else {
+				lw.fmt.Rainbow.Fprintf(l.Step, s.Out, "[%s] ", l.Step)
+			}
 			fmt.Fprintf(s.Out, "%s\n", l.Log)
 		case e, ok := <-errC:
 			if !ok {
@@ -57,3 +74,89 @@ func (lw *LogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
 		}
 	}
 }
+
+// ErrorRecord is the type of the records reporting an error
+const ErrorRecord = "error"
+
+// LogRecord is the JSON object written for each log line, step event and
+// error with the json output
+type LogRecord struct {
+	Type        string     `json:"type"`
+	Pipeline    string     `json:"pipeline"`
+	PipelineRun string     `json:"pipelinerun"`
+	Task        string     `json:"task"`
+	TaskRun     string     `json:"taskrun"`
+	Step        string     `json:"step"`
+	Container   string     `json:"container"`
+	Pod         string     `json:"pod"`
+	Timestamp   *time.Time `json:"timestamp"`
+	Message     string     `json:"message"`
+	ExitCode    *int32     `json:"exitCode,omitempty"`
+	Attempt     int        `json:"attempt,omitempty"`
+}
+
+// SetTimestamp sets the timestamp of the record, which stays null when t
+// is unknown
+func (r *LogRecord) SetTimestamp(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	t = t.UTC()
+	r.Timestamp = &t
+}
+
+// Record returns the JSON record of the log
+func (l Log) Record() LogRecord {
+	r := LogRecord{
+		Type:      string(l.Type),
+		Task:      l.Task,
+		TaskRun:   l.TaskRun,
+		Step:      l.Step,
+		Container: l.Container,
+		Pod:       l.Pod,
+		Message:   l.Log,
+		ExitCode:  l.ExitCode,
+		Attempt:   l.Attempt,
+	}
+	r.SetTimestamp(l.Timestamp)
+	return r
+}
+
+// WriteRecord writes r as JSON on its own line
+func WriteRecord(w io.Writer, r LogRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r)
+}
+
+// JSONLogWriter writes the logs, step events and errors as JSON lines on
+// the output stream
+type JSONLogWriter struct {
+	// TaskRun is the name of the run reported in the records of errors
+	TaskRun string
+}
+
+func (w *JSONLogWriter) Write(s *cli.Stream, logC <-chan Log, errC <-chan error) {
+	for logC != nil || errC != nil {
+		select {
+		case l, ok := <-logC:
+			if !ok {
+				logC = nil
+				continue
+			}
+			if err := WriteRecord(s.Out, l.Record()); err != nil {
+				fmt.Fprintf(s.Err, "failed to write log: %s\n", err)
+			}
+
+		case e, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			r := LogRecord{Type: ErrorRecord, TaskRun: w.TaskRun, Message: e.Error()}
+			if err := WriteRecord(s.Out, r); err != nil {
+				fmt.Fprintf(s.Err, "failed to write error: %s\n", err)
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package taskrun
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -40,6 +41,14 @@ func logCommand(p cli.Params) *cobra.Command {
 Show the live logs of TaskRun named 'foo' from namespace 'bar':
 
     tkn taskrun logs -f foo -n bar
+
+Show the last 20 lines of each step of TaskRun named 'foo' with their timestamps:
+
+    tkn taskrun logs foo --tail 20 --timestamps -n bar
+
+Show the logs of the step 'test' and of the sidecar 'db' of TaskRun named 'foo':
+
+    tkn taskrun logs foo --step test --step db --sidecars -n bar
 `
 	c := &cobra.Command{
 		Use:          "logs",
@@ -63,19 +72,30 @@ Show the live logs of TaskRun named 'foo' from namespace 'bar':
 				return err
 			}
 
-			return Run(opts)
+			ctx, cancel := cli.InterruptContext()
+			defer cancel()
+
+			return Run(ctx, opts)
 		},
 	}
 
 	c.Flags().BoolVarP(&opts.AllSteps, "all", "a", false, "show all logs including init steps injected by tekton")
 	c.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "stream live logs")
+	c.Flags().StringSliceVarP(&opts.Steps, "step", "", []string{}, "show logs for mentioned steps only, can be repeated")
+	c.Flags().BoolVarP(&opts.Sidecars, "sidecars", "", false, "show logs of the sidecars after the ones of the steps")
+	c.Flags().StringVarP(&opts.Attempt, "attempt", "", "", "show logs of the given attempt of tasks with retries, starting at 1, or of all of them with 'all' (default: latest)")
 	c.Flags().IntVarP(&opts.Limit, "limit", "", 5, "lists number of taskruns")
+	c.Flags().BoolVarP(&opts.Timestamps, "timestamps", "", false, "show the timestamp of each log line")
+	c.Flags().DurationVarP(&opts.Since, "since", "", 0, "only show logs newer than a relative duration like 30s, 10m or 2h")
+	c.Flags().StringVarP(&opts.SinceTime, "since-time", "", "", "only show logs after a date in RFC3339 format")
+	c.Flags().Int64VarP(&opts.Tail, "tail", "", 0, "number of most recent lines to show for each step (default: all)")
+	c.Flags().StringVarP(&opts.Output, "output", "o", "", "output format of the logs, json writes one JSON object per line (default: text)")
 
 	_ = c.MarkZshCompPositionalArgumentCustom(1, "__tkn_get_taskrun")
 	return c
 }
 
-func Run(opts *options.LogOptions) error {
+func Run(ctx context.Context, opts *options.LogOptions) error {
 	if opts.TaskrunName == "" {
 		if err := askRunName(opts); err != nil {
 			return err
@@ -92,21 +112,49 @@ func Run(opts *options.LogOptions) error {
 		return err
 	}
 
+	logOpts, err := opts.PodLogOptions()
+	if err != nil {
+		return err
+	}
+
+	jsonOutput, err := opts.JSONOutput()
+	if err != nil {
+		return err
+	}
+
+	attempt, err := opts.AttemptNumber()
+	if err != nil {
+		return err
+	}
+
 	lr := &LogReader{
-		Run:      opts.TaskrunName,
-		Ns:       opts.Params.Namespace(),
-		Clients:  cs,
-		Streamer: streamer,
-		Stream:   opts.Stream,
-		Follow:   opts.Follow,
-		AllSteps: opts.AllSteps,
+		Run:        opts.TaskrunName,
+		Ns:         opts.Params.Namespace(),
+		Clients:    cs,
+		Streamer:   streamer,
+		Stream:     opts.Stream,
+		Follow:     opts.Follow,
+		AllSteps:   opts.AllSteps,
+		Steps:      opts.Steps,
+		Sidecars:   opts.Sidecars,
+		Attempt:    attempt,
+		LogOptions: logOpts,
 	}
 
-	logC, errC, err := lr.Read()
+	logC, errC, err := lr.Read(ctx)
 	if err != nil {
+		// interrupted while waiting for the run to start
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
 	}
 
+	if jsonOutput {
+		(&JSONLogWriter{TaskRun: opts.TaskrunName}).Write(opts.Stream, logC, errC)
+		return nil
+	}
+
 	NewLogWriter().Write(opts.Stream, logC, errC)
 	return nil
 }
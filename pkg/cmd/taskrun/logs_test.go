@@ -16,6 +16,7 @@ package taskrun
 
 import (
 	"bytes"
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -34,6 +35,7 @@ import (
 	tb "github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	k8stest "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
@@ -248,6 +250,414 @@ func TestLog_taskrun_logs(t *testing.T) {
 	test.AssertOutput(t, expected, output)
 }
 
+func TestLog_taskrun_timestamps_and_tail(t *testing.T) {
+	var (
+		ns          = "namespace"
+		taskName    = "output-task"
+		trName      = "output-task-1"
+		trStartTime = clockwork.NewFakeClock().Now().Add(20 * time.Second)
+		trPod       = "output-task-pod-123456"
+		trStep1Name = "writefile-step"
+		nopStep     = "nop"
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunStatus(
+				tb.PodName(trPod),
+				tb.TaskRunStartTime(trStartTime),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName(trStep1Name),
+					tb.StateTerminated(0),
+				),
+				tb.StepState(
+					cb.StepName(nopStep),
+					tb.StateTerminated(0),
+				),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskName),
+			),
+		),
+	}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	ps := []*corev1.Pod{
+		tb.Pod(trPod, ns,
+			tb.PodSpec(
+				tb.PodContainer(trStep1Name, trStep1Name+":latest"),
+				tb.PodContainer(nopStep, "override-with-nop:latest"),
+			),
+			cb.PodStatus(
+				cb.PodPhase(corev1.PodSucceeded),
+			),
+		),
+	}
+
+	logs := fake.Logs(
+		fake.Task(trPod,
+			fake.Step(trStep1Name,
+				"2019-12-02T10:00:00.5Z wrote a file",
+				"2019-12-02T10:00:01Z wrote another file",
+			),
+			fake.Step(nopStep, "2019-12-02T10:00:02.123456789Z Build successful"),
+		),
+	)
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: ps, Namespaces: nsList})
+	trlo := logOpts(trName, ns, cs, fake.Streamer(logs), false, false)
+	trlo.Timestamps = true
+	trlo.Tail = 1
+	output, err := fetchLogs(trlo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedLogs := []string{
+		"2019-12-02T10:00:01.000000000Z [writefile-step] wrote another file\n",
+		"2019-12-02T10:00:02.123456789Z [nop] Build successful\n",
+	}
+	expected := strings.Join(expectedLogs, "\n") + "\n"
+
+	test.AssertOutput(t, expected, output)
+}
+
+func TestLog_taskrun_since_and_since_time(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{})
+
+	trlo := logOpts("output-task-1", "namespace", cs, fake.Streamer(fake.Logs()), false, false)
+	trlo.Since = 10 * time.Minute
+	trlo.SinceTime = "2019-12-02T10:00:00Z"
+	_, err := fetchLogs(trlo)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	test.AssertOutput(t, "only one of --since and --since-time can be used", err.Error())
+
+	trlo.Since = 0
+	trlo.SinceTime = "yesterday"
+	_, err = fetchLogs(trlo)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	test.AssertOutput(t, `since-time "yesterday" is not a RFC3339 date: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`, err.Error())
+}
+
+func TestLog_taskrun_json_output(t *testing.T) {
+	var (
+		ns          = "namespace"
+		taskName    = "output-task"
+		trName      = "output-task-1"
+		trStartTime = clockwork.NewFakeClock().Now().Add(20 * time.Second)
+		trPod       = "output-task-pod-123456"
+		trStep1Name = "writefile-step"
+		nopStep     = "nop"
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunStatus(
+				tb.PodName(trPod),
+				tb.TaskRunStartTime(trStartTime),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName(trStep1Name),
+					tb.StateTerminated(0),
+				),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskName),
+			),
+		),
+	}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	finished := metav1.NewTime(time.Date(2019, 12, 2, 10, 0, 3, 0, time.UTC))
+	ps := []*corev1.Pod{
+		tb.Pod(trPod, ns,
+			tb.PodSpec(
+				tb.PodContainer(trStep1Name, trStep1Name+":latest"),
+			),
+			cb.PodStatus(
+				cb.PodPhase(corev1.PodSucceeded),
+			),
+		),
+	}
+	ps[0].Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: trStep1Name,
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 2, FinishedAt: finished},
+		},
+	}}
+
+	logs := fake.Logs(
+		fake.Task(trPod,
+			fake.Step(trStep1Name, "2019-12-02T10:00:01Z wrote a <file>"),
+			fake.Step(nopStep, "Build successful"),
+		),
+	)
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: ps, Namespaces: nsList})
+	trlo := logOpts(trName, ns, cs, fake.Streamer(logs), false, false)
+	trlo.Output = "json"
+	output, err := fetchLogs(trlo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"type":"step-start","pipeline":"","pipelinerun":"","task":"output-task","taskrun":"output-task-1","step":"writefile-step","container":"writefile-step","pod":"output-task-pod-123456","timestamp":null,"message":""}
+{"type":"log","pipeline":"","pipelinerun":"","task":"output-task","taskrun":"output-task-1","step":"writefile-step","container":"writefile-step","pod":"output-task-pod-123456","timestamp":"2019-12-02T10:00:01Z","message":"wrote a <file>"}
+{"type":"step-end","pipeline":"","pipelinerun":"","task":"output-task","taskrun":"output-task-1","step":"writefile-step","container":"writefile-step","pod":"output-task-pod-123456","timestamp":"2019-12-02T10:00:03Z","message":"","exitCode":2}
+`
+	test.AssertOutput(t, expected, output)
+
+	trlo.Output = "yaml"
+	_, err = fetchLogs(trlo)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	test.AssertOutput(t, `output format "yaml" is not supported, use json`, err.Error())
+}
+
+func TestLog_taskrun_steps_and_sidecars(t *testing.T) {
+	var (
+		ns          = "namespace"
+		taskName    = "output-task"
+		trName      = "output-task-1"
+		trStartTime = clockwork.NewFakeClock().Now().Add(20 * time.Second)
+		trPod       = "output-task-pod-123456"
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunStatus(
+				tb.PodName(trPod),
+				tb.TaskRunStartTime(trStartTime),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.StepState(
+					cb.StepName("build"),
+					tb.StateTerminated(0),
+				),
+				tb.StepState(
+					cb.StepName("test"),
+					tb.StateTerminated(0),
+				),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskName),
+			),
+		),
+	}
+	trs[0].Status.Sidecars = []v1alpha1.SidecarState{{Name: "db"}}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	ps := []*corev1.Pod{
+		tb.Pod(trPod, ns,
+			tb.PodSpec(
+				tb.PodContainer("step-build", "build:latest"),
+				tb.PodContainer("step-test", "test:latest"),
+				tb.PodContainer("sidecar-db", "postgres:latest"),
+			),
+			cb.PodStatus(
+				cb.PodPhase(corev1.PodSucceeded),
+			),
+		),
+	}
+
+	logs := fake.Logs(
+		fake.Task(trPod,
+			fake.Step("step-build", "built"),
+			fake.Step("step-test", "tested"),
+			fake.Step("sidecar-db", "database ready"),
+		),
+	)
+
+	scenarios := []struct {
+		name     string
+		steps    []string
+		sidecars bool
+		expected []string
+	}{
+		{
+			name:     "steps only",
+			expected: []string{"[build] built\n", "[test] tested\n"},
+		},
+		{
+			name:     "selected step",
+			steps:    []string{"test"},
+			expected: []string{"[test] tested\n"},
+		},
+		{
+			name:     "steps and sidecars",
+			sidecars: true,
+			expected: []string{"[build] built\n", "[test] tested\n", "[sidecar-db] database ready\n"},
+		},
+		{
+			name:     "selected sidecar",
+			steps:    []string{"db"},
+			sidecars: true,
+			expected: []string{"[sidecar-db] database ready\n"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: ps, Namespaces: nsList})
+			trlo := logOpts(trName, ns, cs, fake.Streamer(logs), false, false)
+			trlo.Steps = s.steps
+			trlo.Sidecars = s.sidecars
+			output, err := fetchLogs(trlo)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			test.AssertOutput(t, strings.Join(s.expected, "\n")+"\n", output)
+		})
+	}
+}
+
+func TestLog_taskrun_attempts(t *testing.T) {
+	var (
+		ns          = "namespace"
+		taskName    = "output-task"
+		trName      = "output-task-1"
+		trStartTime = clockwork.NewFakeClock().Now().Add(20 * time.Second)
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunStatus(
+				tb.PodName("output-task-pod-3"),
+				tb.TaskRunStartTime(trStartTime),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskName),
+			),
+		),
+	}
+	trs[0].Status.RetriesStatus = []v1alpha1.TaskRunStatus{
+		{PodName: "output-task-pod-1"},
+		{PodName: "output-task-pod-2"},
+	}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	// the pod of the second attempt has been garbage collected
+	ps := []*corev1.Pod{
+		tb.Pod("output-task-pod-1", ns,
+			tb.PodSpec(
+				tb.PodContainer("step-test", "test:latest"),
+			),
+		),
+		tb.Pod("output-task-pod-3", ns,
+			tb.PodSpec(
+				tb.PodContainer("step-test", "test:latest"),
+			),
+		),
+	}
+
+	logs := fake.Logs(
+		fake.Task("output-task-pod-1", fake.Step("step-test", "connection refused")),
+		fake.Task("output-task-pod-3", fake.Step("step-test", "tests passed")),
+	)
+
+	scenarios := []struct {
+		name     string
+		attempt  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "latest attempt",
+			expected: "[test] tests passed\n\n",
+		},
+		{
+			name:     "first attempt",
+			attempt:  "1",
+			expected: "[test (attempt 1)] connection refused\n\n",
+		},
+		{
+			name:    "all attempts",
+			attempt: "all",
+			expected: "[test (attempt 1)] connection refused\n\n" +
+				"pod output-task-pod-2 of attempt 2 of task output-task has been deleted, its logs are no longer available\n" +
+				"[test (attempt 3)] tests passed\n\n",
+		},
+		{
+			name:    "unknown attempt",
+			attempt: "4",
+			err:     "task output-task has 3 attempts, attempt 4 does not exist",
+		},
+		{
+			name:    "invalid attempt",
+			attempt: "first",
+			err:     "attempt was \"first\" but must be a positive number or all",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: ps, Namespaces: nsList})
+			trlo := logOpts(trName, ns, cs, fake.Streamer(logs), false, false)
+			trlo.Attempt = s.attempt
+			output, err := fetchLogs(trlo)
+			if s.err != "" {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				test.AssertOutput(t, s.err, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			test.AssertOutput(t, s.expected, output)
+		})
+	}
+}
+
 func TestLog_taskrun_logs_no_pod_name(t *testing.T) {
 	var (
 		ns          = "namespace"
@@ -827,6 +1237,115 @@ func TestLog_taskrun_follow_mode_no_output_provided(t *testing.T) {
 	test.AssertOutput(t, expected, err.Error())
 }
 
+func TestLog_taskrun_follow_mode_hints(t *testing.T) {
+	var (
+		ns     = "namespace"
+		trName = "output-task-run"
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionUnknown,
+					Reason:  "ExceededResourceQuota",
+					Message: "exceeded quota: compute",
+				}),
+			),
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("output-task"),
+				tb.TaskRunServiceAccountName("builder"),
+			),
+		),
+	}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Namespaces: nsList})
+	watcher := watch.NewRaceFreeFake()
+	cs.Pipeline.PrependWatchReactor("taskruns", k8stest.DefaultWatchReactor(watcher, nil))
+	trlo := logOpts(trName, ns, cs, fake.Streamer(fake.Logs()), false, true)
+
+	_, err := fetchLogs(trlo)
+	if err == nil {
+		t.Fatal("Expecting an error but it's empty")
+	}
+
+	// the problems keeping the pod from being created are shown with the
+	// error
+	expectedErr := "task output-task create has not started yet or pod for task not yet available\n" +
+		"ExceededQuota: exceeded quota: compute\n" +
+		"  hint: wait for other runs to complete, raise the resource quota of namespace namespace or lower the resource requests of the task\n" +
+		"MissingServiceAccount: service account builder does not exist in namespace namespace\n" +
+		"  hint: create the service account or run the task with an existing one"
+	test.AssertOutput(t, expectedErr, err.Error())
+}
+
+func TestLog_taskrun_follow_mode_hints_latest(t *testing.T) {
+	var (
+		ns     = "namespace"
+		trName = "output-task-run"
+	)
+
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("output-task"),
+			),
+		),
+	}
+
+	nsList := []*corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace",
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Namespaces: nsList})
+	watcher := watch.NewRaceFreeFake()
+	cs.Pipeline.PrependWatchReactor("taskruns", k8stest.DefaultWatchReactor(watcher, nil))
+
+	// the quota is exceeded once the pod of the run is waited for
+	waiting := false
+	cs.Pipeline.PrependWatchReactor("taskruns", func(action k8stest.Action) (bool, watch.Interface, error) {
+		waiting = true
+		return false, nil, nil
+	})
+	cs.Pipeline.PrependReactor("get", "taskruns", func(action k8stest.Action) (bool, runtime.Object, error) {
+		if !waiting {
+			return false, nil, nil
+		}
+		tr := trs[0].DeepCopy()
+		tr.Status.Conditions = append(tr.Status.Conditions, apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  "ExceededResourceQuota",
+			Message: "exceeded quota: compute",
+		})
+		return true, tr, nil
+	})
+	trlo := logOpts(trName, ns, cs, fake.Streamer(fake.Logs()), false, true)
+
+	_, err := fetchLogs(trlo)
+	if err == nil {
+		t.Fatal("Expecting an error but it's empty")
+	}
+
+	expectedErr := "task output-task create has not started yet or pod for task not yet available\n" +
+		"ExceededQuota: exceeded quota: compute\n" +
+		"  hint: wait for other runs to complete, raise the resource quota of namespace namespace or lower the resource requests of the task"
+	test.AssertOutput(t, expectedErr, err.Error())
+}
+
 func logOpts(run, ns string, cs pipelinetest.Clients, streamer stream.NewStreamerFunc, allSteps bool, follow bool) *options.LogOptions {
 	p := test.Params{
 		Kube:   cs.Kube,
@@ -848,7 +1367,7 @@ func fetchLogs(lo *options.LogOptions) (string, error) {
 	out := new(bytes.Buffer)
 	lo.Stream = &cli.Stream{Out: out, Err: out}
 
-	err := Run(lo)
+	err := Run(context.Background(), lo)
 
 	return out.String(), err
 }
@@ -40,6 +40,7 @@ func Command(p cli.Params) *cobra.Command {
 		deleteCommand(p),
 		cancelCommand(p),
 		describeCommand(p),
+		diagnoseCommand(p),
 	)
 
 	return cmd
@@ -34,6 +34,11 @@ var clientVersion = devVersion
 const devVersion = "dev"
 const latestReleaseURL = "https://api.github.com/repos/tektoncd/cli/releases/latest"
 
+// ClientVersion returns the version of tkn
+func ClientVersion() string {
+	return clientVersion
+}
+
 // Command returns version command
 func Command() *cobra.Command {
 	var check bool
@@ -16,6 +16,7 @@ package formatted
 
 import (
 	"io"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -79,8 +80,9 @@ func (r *rainbow) Fprintf(label string, w io.Writer, format string, args ...inte
 type Color struct {
 	Rainbow *rainbow
 
-	red  *color.Color
-	blue *color.Color
+	red       *color.Color
+	blue      *color.Color
+	highlight *color.Color
 }
 
 //NewColor returns a new instance color formatter
@@ -88,8 +90,9 @@ func NewColor() *Color {
 	return &Color{
 		Rainbow: newRainbow(),
 
-		red:  color.New(color.FgRed),
-		blue: color.New(color.FgBlue),
+		red:       color.New(color.FgRed),
+		blue:      color.New(color.FgBlue),
+		highlight: color.New(color.Bold, color.ReverseVideo),
 	}
 }
 
@@ -102,3 +105,17 @@ func (c *Color) PrintRed(w io.Writer, format string, args ...interface{}) {
 func (c *Color) Error(w io.Writer, format string, args ...interface{}) {
 	c.PrintRed(w, format, args...)
 }
+
+//Highlight returns s with the parts between the pairs of indices, as
+//returned by regexp FindAllStringIndex, highlighted
+func (c *Color) Highlight(s string, indices [][]int) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range indices {
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(c.highlight.Sprint(s[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -15,6 +15,8 @@
 package formatted
 
 import (
+	"time"
+
 	"github.com/hako/durafmt"
 	"github.com/jonboulle/clockwork"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,3 +39,13 @@ func Duration(t1, t2 *metav1.Time) string {
 	dur := t2.Time.Sub(t1.Time)
 	return durafmt.ParseShort(dur).String()
 }
+
+// timestampLayout keeps the nanoseconds zeros so that every timestamp has
+// the same width
+const timestampLayout = "2006-01-02T15:04:05.000000000Z"
+
+// Timestamp returns the UTC time t with a fixed width, to be printed as a
+// column in front of log lines
+func Timestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
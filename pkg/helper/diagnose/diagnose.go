@@ -0,0 +1,324 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/cli/pkg/helper/events"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ReasonImagePull is reported when the image of a step cannot be pulled
+	ReasonImagePull = "ImagePullBackOff"
+	// ReasonUnschedulable is reported when no node can run the pod
+	ReasonUnschedulable = "Unschedulable"
+	// ReasonUnboundPVC is reported when a volume claim of the pod is
+	// missing or not bound
+	ReasonUnboundPVC = "UnboundPersistentVolumeClaim"
+	// ReasonMissingSecret is reported when a secret used by the pod does
+	// not exist
+	ReasonMissingSecret = "MissingSecret"
+	// ReasonMissingServiceAccount is reported when the service account of
+	// the TaskRun does not exist
+	ReasonMissingServiceAccount = "MissingServiceAccount"
+	// ReasonOOMKilled is reported when a step ran out of memory
+	ReasonOOMKilled = "OOMKilled"
+	// ReasonQuotaExceeded is reported when a resource quota of the
+	// namespace rejects the pod
+	ReasonQuotaExceeded = "ExceededQuota"
+)
+
+var missingSecret = regexp.MustCompile(`secrets? "([^"]+)" not found`)
+
+// Problem is a reason why a TaskRun is stuck or has failed, with a
+// suggested fix
+type Problem struct {
+	Reason  string
+	Message string
+	Fix     string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Reason, p.Message)
+}
+
+// problems collects the problems found, once each
+type problems struct {
+	list []Problem
+	seen map[string]bool
+}
+
+func (ps *problems) add(p Problem) {
+	if ps.seen == nil {
+		ps.seen = map[string]bool{}
+	}
+
+	key := p.Reason + "/" + p.Message
+	if ps.seen[key] {
+		return
+	}
+	ps.seen[key] = true
+	ps.list = append(ps.list, p)
+}
+
+func (ps *problems) has(reason string) bool {
+	for _, p := range ps.list {
+		if p.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Pod returns the problems shown by the status of the pod of a TaskRun
+func Pod(pod *corev1.Pod) []Problem {
+	ps := &problems{}
+	podProblems(ps, pod)
+	return ps.list
+}
+
+func podProblems(ps *problems, pod *corev1.Pod) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			ps.add(unschedulable(pod.Name, c.Message))
+		}
+	}
+
+	images := map[string]string{}
+	limits := map[string]string{}
+	for _, c := range containers(pod) {
+		images[c.Name] = c.Image
+		if m, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			limits[c.Name] = m.String()
+		}
+	}
+
+	statuses := []corev1.ContainerStatus{}
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		step := strings.TrimPrefix(cs.Name, "step-")
+
+		if w := cs.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+				image := images[cs.Name]
+				if image == "" {
+					image = cs.Image
+				}
+				ps.add(Problem{
+					Reason:  ReasonImagePull,
+					Message: fmt.Sprintf("step %s cannot pull image %q: %s", step, image, w.Message),
+					Fix:     "check the name and the tag of the image, and that the service account of the TaskRun has a pull secret for its registry",
+				})
+			case "CreateContainerConfigError":
+				if m := missingSecret.FindStringSubmatch(w.Message); m != nil {
+					ps.add(secretNotFound(m[1], pod.Namespace))
+				}
+			}
+		}
+
+		for _, state := range []corev1.ContainerState{cs.State, cs.LastTerminationState} {
+			if t := state.Terminated; t != nil && t.Reason == "OOMKilled" {
+				msg := fmt.Sprintf("step %s was killed as it ran out of memory", step)
+				if limit, ok := limits[cs.Name]; ok {
+					msg = fmt.Sprintf("%s (limit %s)", msg, limit)
+				}
+				ps.add(Problem{
+					Reason:  ReasonOOMKilled,
+					Message: msg,
+					Fix:     "raise the memory limit of the step or lower its memory usage",
+				})
+			}
+		}
+	}
+}
+
+// TaskRun returns the problems of the TaskRun, found in its status, the
+// status of its pod, the objects the pod needs and the warning events of
+// the namespace
+func TaskRun(kube k8s.Interface, tr *v1alpha1.TaskRun) ([]Problem, error) {
+	ps := &problems{}
+	ns := tr.Namespace
+
+	if len(tr.Status.Conditions) != 0 && tr.Status.PodName == "" {
+		if msg := tr.Status.Conditions[0].Message; isQuotaError(msg) {
+			ps.add(quotaExceeded(ns, msg))
+		}
+	}
+
+	// the default service account used otherwise exists in every namespace
+	if sa := tr.Spec.ServiceAccountName; sa != "" {
+		if _, err := kube.CoreV1().ServiceAccounts(ns).Get(sa, metav1.GetOptions{}); errors.IsNotFound(err) {
+			ps.add(Problem{
+				Reason:  ReasonMissingServiceAccount,
+				Message: fmt.Sprintf("service account %s does not exist in namespace %s", sa, ns),
+				Fix:     "create the service account or run the task with an existing one",
+			})
+		}
+	}
+
+	if tr.Status.PodName != "" {
+		pod, err := kube.CoreV1().Pods(ns).Get(tr.Status.PodName, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			return nil, err
+		default:
+			podProblems(ps, pod)
+			if err := volumeProblems(ps, kube, pod); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := eventProblems(ps, kube, tr); err != nil {
+		return nil, err
+	}
+
+	return ps.list, nil
+}
+
+func volumeProblems(ps *problems, kube k8s.Interface, pod *corev1.Pod) error {
+	secrets := []string{}
+	for _, s := range pod.Spec.ImagePullSecrets {
+		secrets = append(secrets, s.Name)
+	}
+
+	for _, v := range pod.Spec.Volumes {
+		if s := v.Secret; s != nil && (s.Optional == nil || !*s.Optional) {
+			secrets = append(secrets, s.SecretName)
+		}
+
+		pvc := v.PersistentVolumeClaim
+		if pvc == nil {
+			continue
+		}
+
+		claim, err := kube.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(pvc.ClaimName, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			ps.add(Problem{
+				Reason:  ReasonUnboundPVC,
+				Message: fmt.Sprintf("persistent volume claim %s does not exist", pvc.ClaimName),
+				Fix:     "create the persistent volume claim or fix the workspace of the TaskRun using it",
+			})
+		case err != nil:
+			return err
+		case claim.Status.Phase != corev1.ClaimBound:
+			ps.add(Problem{
+				Reason:  ReasonUnboundPVC,
+				Message: fmt.Sprintf("persistent volume claim %s is not bound", pvc.ClaimName),
+				Fix:     "check that its storage class exists and can provision a volume, or that a persistent volume matches it",
+			})
+		}
+	}
+
+	for _, c := range containers(pod) {
+		for _, e := range c.EnvFrom {
+			if r := e.SecretRef; r != nil && (r.Optional == nil || !*r.Optional) {
+				secrets = append(secrets, r.Name)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if r := e.ValueFrom.SecretKeyRef; r != nil && (r.Optional == nil || !*r.Optional) {
+				secrets = append(secrets, r.Name)
+			}
+		}
+	}
+
+	for _, name := range secrets {
+		_, err := kube.CoreV1().Secrets(pod.Namespace).Get(name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			ps.add(secretNotFound(name, pod.Namespace))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eventProblems(ps *problems, kube k8s.Interface, tr *v1alpha1.TaskRun) error {
+	evs, err := events.List(kube, tr.Namespace,
+		corev1.ObjectReference{Kind: "TaskRun", Name: tr.Name, UID: tr.UID},
+		corev1.ObjectReference{Kind: "Pod", Name: tr.Status.PodName},
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range evs {
+		if e.Type != corev1.EventTypeWarning {
+			continue
+		}
+
+		switch {
+		case isQuotaError(e.Message):
+			ps.add(quotaExceeded(tr.Namespace, e.Message))
+		case e.Reason == "FailedScheduling" && !ps.has(ReasonUnschedulable):
+			ps.add(unschedulable(tr.Status.PodName, e.Message))
+		}
+	}
+
+	return nil
+}
+
+func containers(pod *corev1.Pod) []corev1.Container {
+	all := []corev1.Container{}
+	all = append(all, pod.Spec.InitContainers...)
+	return append(all, pod.Spec.Containers...)
+}
+
+func isQuotaError(msg string) bool {
+	return strings.Contains(msg, "exceeded quota")
+}
+
+func unschedulable(pod, msg string) Problem {
+	return Problem{
+		Reason:  ReasonUnschedulable,
+		Message: fmt.Sprintf("pod %s cannot be scheduled: %s", pod, msg),
+		Fix:     "add nodes matching the node selector, affinity and tolerations of the task, or lower its resource requests",
+	}
+}
+
+func secretNotFound(name, ns string) Problem {
+	return Problem{
+		Reason:  ReasonMissingSecret,
+		Message: fmt.Sprintf("secret %s does not exist in namespace %s", name, ns),
+		Fix:     "create the secret or fix its reference in the task",
+	}
+}
+
+func quotaExceeded(ns, msg string) Problem {
+	return Problem{
+		Reason:  ReasonQuotaExceeded,
+		Message: msg,
+		Fix:     fmt.Sprintf("wait for other runs to complete, raise the resource quota of namespace %s or lower the resource requests of the task", ns),
+	}
+}
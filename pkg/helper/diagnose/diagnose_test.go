@@ -0,0 +1,195 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinetest "github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func reasons(problems []Problem) []string {
+	r := []string{}
+	for _, p := range problems {
+		r = append(r, p.String())
+	}
+	return r
+}
+
+func TestPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-pod", Namespace: "ns"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "step-build", Image: "golang:nope"},
+				{Name: "step-test", Image: "golang:1.13", Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+				}},
+				{Name: "step-push", Image: "kaniko"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient cpu.",
+				},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "step-build", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "ImagePullBackOff",
+					Message: "Back-off pulling image",
+				}}},
+				{Name: "step-test", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:   "OOMKilled",
+					ExitCode: 137,
+				}}},
+				{Name: "step-push", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CreateContainerConfigError",
+					Message: `secret "registry" not found`,
+				}}},
+			},
+		},
+	}
+
+	test.AssertOutput(t, []string{
+		"Unschedulable: pod build-pod cannot be scheduled: 0/3 nodes are available: 3 Insufficient cpu.",
+		`ImagePullBackOff: step build cannot pull image "golang:nope": Back-off pulling image`,
+		"OOMKilled: step test was killed as it ran out of memory (limit 64Mi)",
+		"MissingSecret: secret registry does not exist in namespace ns",
+	}, reasons(Pod(pod)))
+}
+
+func TestTaskRun(t *testing.T) {
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("build-1", "ns",
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef("build"),
+				tb.TaskRunServiceAccountName("builder"),
+			),
+			tb.TaskRunStatus(
+				tb.PodName("build-1-pod"),
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: "Pending",
+				}),
+			),
+		),
+	}
+
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-1-pod", Namespace: "ns"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "step-build", Image: "golang"},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "source", VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"},
+					}},
+					{Name: "cache", VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache"},
+					}},
+					{Name: "creds", VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "git-creds"},
+					}},
+				},
+			},
+		},
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs, Pods: pods})
+	kube := cs.Kube.CoreV1()
+
+	if _, err := kube.PersistentVolumeClaims("ns").Create(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "ns"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	events := []*corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "build-1-pod"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedScheduling",
+			Message:        "pod has unbound immediate PersistentVolumeClaims",
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "ns"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "other-pod"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedScheduling",
+			Message:        "not about this run",
+		},
+	}
+	for _, e := range events {
+		if _, err := kube.Events("ns").Create(e); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	problems, err := TaskRun(cs.Kube, trs[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, []string{
+		"MissingServiceAccount: service account builder does not exist in namespace ns",
+		"UnboundPersistentVolumeClaim: persistent volume claim source is not bound",
+		"UnboundPersistentVolumeClaim: persistent volume claim cache does not exist",
+		"MissingSecret: secret git-creds does not exist in namespace ns",
+		"Unschedulable: pod build-1-pod cannot be scheduled: pod has unbound immediate PersistentVolumeClaims",
+	}, reasons(problems))
+}
+
+func TestTaskRun_quota(t *testing.T) {
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("build-1", "ns",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build")),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionUnknown,
+					Reason:  "ExceededResourceQuota",
+					Message: `pods "build-1-pod" is forbidden: exceeded quota: compute, requested: cpu=2, used: cpu=4, limited: cpu=4`,
+				}),
+			),
+		),
+	}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{TaskRuns: trs})
+
+	problems, err := TaskRun(cs.Kube, trs[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, 1, len(problems))
+	test.AssertOutput(t, ReasonQuotaExceeded, problems[0].Reason)
+	test.AssertOutput(t, "wait for other runs to complete, raise the resource quota of namespace ns or lower the resource requests of the task", problems[0].Fix)
+}
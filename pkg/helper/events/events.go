@@ -0,0 +1,201 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/formatted"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// Event is an event of an object, repeated Count times
+type Event struct {
+	Type      string
+	Reason    string
+	Object    string
+	Message   string
+	Count     int32
+	FirstSeen metav1.Time
+	LastSeen  metav1.Time
+}
+
+// maxSelected is the number of objects whose events are listed with a field
+// selector each, the events of the namespace are listed once for more objects
+const maxSelected = 4
+
+// List returns the events of these objects of namespace ns, sorted by the
+// time they were last seen. The objects are matched by name, and by kind and
+// uid when they are set. The same event reported several times for an object
+// is only listed once.
+func List(kube k8s.Interface, ns string, objects ...corev1.ObjectReference) ([]Event, error) {
+	refs := []corev1.ObjectReference{}
+	listed := map[corev1.ObjectReference]bool{}
+	for _, o := range objects {
+		if o.Name == "" || listed[o] {
+			continue
+		}
+		listed[o] = true
+		refs = append(refs, o)
+	}
+
+	items, err := involvedEvents(kube, ns, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []Event{}
+	index := map[string]int{}
+	for _, e := range items {
+		events = add(events, index, e)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.Before(&events[j].LastSeen)
+	})
+
+	return events, nil
+}
+
+// involvedEvents lists the events of the objects with a field selector for
+// each of them, or once for the whole namespace when there are more than
+// maxSelected of them
+func involvedEvents(kube k8s.Interface, ns string, refs []corev1.ObjectReference) ([]corev1.Event, error) {
+	if len(refs) > maxSelected {
+		list, err := kube.CoreV1().Events(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return involving(list.Items, refs...), nil
+	}
+
+	events := []corev1.Event{}
+	for _, o := range refs {
+		list, err := kube.CoreV1().Events(ns).List(metav1.ListOptions{FieldSelector: involvedSelector(o)})
+		if err != nil {
+			return nil, err
+		}
+		// the field selector is not honoured by every client
+		events = append(events, involving(list.Items, o)...)
+	}
+	return events, nil
+}
+
+// involving returns the events involving one of the objects
+func involving(events []corev1.Event, refs ...corev1.ObjectReference) []corev1.Event {
+	kept := []corev1.Event{}
+	for _, e := range events {
+		for _, o := range refs {
+			if involves(e, o) {
+				kept = append(kept, e)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func involvedSelector(o corev1.ObjectReference) string {
+	set := fields.Set{"involvedObject.name": o.Name}
+	if o.Kind != "" {
+		set["involvedObject.kind"] = o.Kind
+	}
+	if o.UID != "" {
+		set["involvedObject.uid"] = string(o.UID)
+	}
+	return set.AsSelector().String()
+}
+
+func involves(e corev1.Event, o corev1.ObjectReference) bool {
+	return e.InvolvedObject.Name == o.Name &&
+		(o.Kind == "" || e.InvolvedObject.Kind == o.Kind) &&
+		(o.UID == "" || e.InvolvedObject.UID == o.UID)
+}
+
+// add appends e to events, or counts it in the event it repeats, index
+// locating the events already listed
+func add(events []Event, index map[string]int, e corev1.Event) []Event {
+	object := e.InvolvedObject.Name
+	if e.InvolvedObject.Kind != "" {
+		object = e.InvolvedObject.Kind + "/" + object
+	}
+
+	first, last := seen(e)
+	count := e.Count
+	if count == 0 {
+		count = 1
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s", object, e.Type, e.Reason, e.Message)
+	if i, ok := index[key]; ok {
+		events[i].Count += count
+		if first.Before(&events[i].FirstSeen) {
+			events[i].FirstSeen = first
+		}
+		if events[i].LastSeen.Before(&last) {
+			events[i].LastSeen = last
+		}
+		return events
+	}
+
+	index[key] = len(events)
+	return append(events, Event{
+		Type:      e.Type,
+		Reason:    e.Reason,
+		Object:    object,
+		Message:   e.Message,
+		Count:     count,
+		FirstSeen: first,
+		LastSeen:  last,
+	})
+}
+
+// seen returns when the event was first and last seen, the events recorded
+// with the events API only have an EventTime
+func seen(e corev1.Event) (metav1.Time, metav1.Time) {
+	first, last := e.FirstTimestamp, e.LastTimestamp
+
+	if last.IsZero() {
+		switch {
+		case !e.EventTime.IsZero():
+			last = metav1.NewTime(e.EventTime.Time)
+		case !first.IsZero():
+			last = first
+		default:
+			last = e.CreationTimestamp
+		}
+	}
+
+	if first.IsZero() {
+		first = last
+	}
+
+	return first, last
+}
+
+// Seen tells when the event was last seen, and how many times it was seen
+// when it was repeated
+func (e Event) Seen(c clockwork.Clock) string {
+	age := formatted.Age(&e.LastSeen, c)
+	if e.Count > 1 {
+		return fmt.Sprintf("%s (x%d)", age, e.Count)
+	}
+	return age
+}
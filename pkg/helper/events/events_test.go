@@ -0,0 +1,121 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stest "k8s.io/client-go/testing"
+)
+
+func event(name, object, reason string, count int32, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        reason + " of " + object,
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(last.Add(-time.Minute)),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestList(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	now := clock.Now()
+
+	kube := fake.NewSimpleClientset(
+		event("e1", "build-pod", "FailedScheduling", 2, now.Add(-3*time.Minute)),
+		event("e2", "test-pod", "BackOff", 1, now.Add(-5*time.Minute)),
+		// the same event recorded again
+		event("e3", "build-pod", "FailedScheduling", 3, now.Add(-time.Minute)),
+		event("e4", "other-pod", "BackOff", 1, now),
+	)
+
+	selectors := []string{}
+	kube.PrependReactor("list", "events", func(action k8stest.Action) (bool, runtime.Object, error) {
+		selectors = append(selectors, action.(k8stest.ListAction).GetListRestrictions().Fields.String())
+		return false, nil, nil
+	})
+
+	events, err := List(kube, "ns",
+		corev1.ObjectReference{Kind: "Pod", Name: "build-pod"},
+		corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+		// events of another kind of object with the same name
+		corev1.ObjectReference{Kind: "TaskRun", Name: "other-pod"},
+		corev1.ObjectReference{},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, []string{
+		"involvedObject.kind=Pod,involvedObject.name=build-pod",
+		"involvedObject.kind=Pod,involvedObject.name=test-pod",
+		"involvedObject.kind=TaskRun,involvedObject.name=other-pod",
+	}, selectors)
+
+	test.AssertOutput(t, 2, len(events))
+
+	test.AssertOutput(t, "Pod/test-pod", events[0].Object)
+	test.AssertOutput(t, "5 minutes ago", events[0].Seen(clock))
+
+	test.AssertOutput(t, "Pod/build-pod", events[1].Object)
+	test.AssertOutput(t, int32(5), events[1].Count)
+	test.AssertOutput(t, now.Add(-4*time.Minute).Unix(), events[1].FirstSeen.Unix())
+	test.AssertOutput(t, "1 minute ago (x5)", events[1].Seen(clock))
+}
+
+func TestList_namespace(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	now := clock.Now()
+
+	kube := fake.NewSimpleClientset(
+		event("e1", "pod-1", "BackOff", 1, now.Add(-3*time.Minute)),
+		event("e2", "pod-5", "BackOff", 1, now.Add(-2*time.Minute)),
+		event("e3", "other-pod", "BackOff", 1, now),
+	)
+
+	selectors := []string{}
+	kube.PrependReactor("list", "events", func(action k8stest.Action) (bool, runtime.Object, error) {
+		selectors = append(selectors, action.(k8stest.ListAction).GetListRestrictions().Fields.String())
+		return false, nil, nil
+	})
+
+	objects := []corev1.ObjectReference{}
+	for _, name := range []string{"pod-1", "pod-2", "pod-3", "pod-4", "pod-5"} {
+		objects = append(objects, corev1.ObjectReference{Kind: "Pod", Name: name})
+	}
+
+	events, err := List(kube, "ns", objects...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the events of the namespace are listed once for that many objects
+	test.AssertOutput(t, []string{""}, selectors)
+
+	test.AssertOutput(t, 2, len(events))
+	test.AssertOutput(t, "Pod/pod-1", events[0].Object)
+	test.AssertOutput(t, "Pod/pod-5", events[1].Object)
+}
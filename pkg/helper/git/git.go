@@ -0,0 +1,232 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+const defaultRemote = "origin"
+
+// Repo is a git working copy on the local filesystem
+type Repo struct {
+	Dir string
+}
+
+// Local returns the git working copy found at dir
+func Local(dir string) (*Repo, error) {
+	r := &Repo{Dir: dir}
+	if _, err := r.git("rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, fmt.Errorf("%s is not a git repository", dir)
+	}
+	return r, nil
+}
+
+func (r *Repo) git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Remote returns the name of the remote tracked by the current branch,
+// falling back to origin when the branch has no upstream
+func (r *Repo) Remote() string {
+	upstream, err := r.git("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	if err != nil || !strings.Contains(upstream, "/") {
+		return defaultRemote
+	}
+	return strings.SplitN(upstream, "/", 2)[0]
+}
+
+// RemoteURL returns the url of the remote tracked by the current branch
+func (r *Repo) RemoteURL() (string, error) {
+	remote := r.Remote()
+	url, err := r.git("config", "--get", "remote."+remote+".url")
+	if err != nil || url == "" {
+		return "", fmt.Errorf("no url configured for git remote %s", remote)
+	}
+	return url, nil
+}
+
+// Revision returns the commit sha of HEAD
+func (r *Repo) Revision() (string, error) {
+	return r.git("rev-parse", "HEAD")
+}
+
+// Branch returns the name of the current branch, or an empty string
+// when HEAD is detached
+func (r *Repo) Branch() string {
+	branch, err := r.git("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// HasUncommittedChanges reports whether the working copy has modified
+// or untracked files
+func (r *Repo) HasUncommittedChanges() (bool, error) {
+	out, err := r.git("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// HasUnpushedCommits reports whether HEAD contains commits which are not
+// on the upstream of the current branch. A branch without upstream is
+// considered unpushed.
+func (r *Repo) HasUnpushedCommits() (bool, error) {
+	if _, err := r.git("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err != nil {
+		return true, nil
+	}
+	out, err := r.git("rev-list", "--count", "@{u}..HEAD")
+	if err != nil {
+		return false, err
+	}
+	return out != "0", nil
+}
+
+// Files returns the paths, relative to Dir, of the tracked files and of the
+// untracked files which are not ignored. Only the files under Dir are listed
+// when it is a subdirectory of the working copy.
+func (r *Repo) Files() ([]string, error) {
+	out, err := r.git("ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Resource describes the state of a local working copy as a git
+// PipelineResource along with warnings about changes that the
+// cluster will not see
+type Resource struct {
+	URL      string
+	Revision string
+	Warnings []string
+}
+
+// FromLocal reads the remote url and HEAD revision of the working copy
+// at dir
+func FromLocal(dir string) (*Resource, error) {
+	repo, err := Local(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := repo.RemoteURL()
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := repo.Revision()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD revision: %s", err)
+	}
+
+	res := &Resource{URL: url, Revision: rev}
+
+	dirty, err := repo.HasUncommittedChanges()
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("uncommitted changes in %s will not be part of the run", dir))
+	}
+
+	unpushed, err := repo.HasUnpushedCommits()
+	if err != nil {
+		return nil, err
+	}
+	if unpushed {
+		branch := repo.Branch()
+		if branch == "" {
+			branch = "HEAD"
+		}
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s has commits which are not pushed to %s, revision %s may not be available to the cluster", branch, repo.Remote(), rev))
+	}
+
+	return res, nil
+}
+
+// Spec returns the inline git PipelineResource spec for the resource
+func (r *Resource) Spec() *v1alpha1.PipelineResourceSpec {
+	return &v1alpha1.PipelineResourceSpec{
+		Type: v1alpha1.PipelineResourceTypeGit,
+		Params: []v1alpha1.ResourceParam{
+			{Name: "url", Value: r.URL},
+			{Name: "revision", Value: r.Revision},
+		},
+	}
+}
+
+// ErrManyResources is returned by FindResourceName when no name is given and
+// more than one git resource is declared
+var ErrManyResources = errors.New("more than one git resource is declared, specify one with --git-from-local=<name>")
+
+// FindResourceName returns the name of the git typed resource declared
+// among resources. When name is not empty it must refer to a declared
+// git resource, otherwise exactly one git resource must be declared.
+func FindResourceName(name string, resources map[string]v1alpha1.PipelineResourceType) (string, error) {
+	if name != "" {
+		t, ok := resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s is not declared", name)
+		}
+		if t != v1alpha1.PipelineResourceTypeGit {
+			return "", fmt.Errorf("resource %s is of type %s, not git", name, t)
+		}
+		return name, nil
+	}
+
+	found := []string{}
+	for n, t := range resources {
+		if t == v1alpha1.PipelineResourceTypeGit {
+			found = append(found, n)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no git resource is declared")
+	case 1:
+		return found[0], nil
+	default:
+		return "", ErrManyResources
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "tkn-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "tkn@example.com"},
+		{"config", "user.name", "tkn"},
+		{"remote", "add", "origin", "https://github.com/tektoncd/cli"},
+		{"commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	return dir
+}
+
+func TestFromLocal(t *testing.T) {
+	dir := initRepo(t)
+	defer os.RemoveAll(dir)
+
+	res, err := FromLocal(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rev, _ := (&Repo{Dir: dir}).Revision()
+	test.AssertOutput(t, "https://github.com/tektoncd/cli", res.URL)
+	test.AssertOutput(t, rev, res.Revision)
+	// branch has no upstream, so commits are reported as unpushed
+	test.AssertOutput(t, 1, len(res.Warnings))
+
+	spec := res.Spec()
+	test.AssertOutput(t, v1alpha1.PipelineResourceTypeGit, spec.Type)
+	test.AssertOutput(t, []v1alpha1.ResourceParam{
+		{Name: "url", Value: "https://github.com/tektoncd/cli"},
+		{Name: "revision", Value: rev},
+	}, spec.Params)
+}
+
+func TestFromLocal_uncommitted(t *testing.T) {
+	dir := initRepo(t)
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := FromLocal(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, "uncommitted changes in "+dir+" will not be part of the run", res.Warnings[0])
+}
+
+func TestRepo_Files_subdirectory(t *testing.T) {
+	dir := initRepo(t)
+	defer os.RemoveAll(dir)
+
+	for _, f := range []string{"top.txt", "app/main.go", "app/cmd/run.go"} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo, err := Local(filepath.Join(dir, "app"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	files, err := repo.Files()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sort.Strings(files)
+	test.AssertOutput(t, []string{"cmd/run.go", "main.go"}, files)
+}
+
+func TestFromLocal_not_a_repository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tkn-nogit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = FromLocal(dir)
+	if err == nil {
+		t.Fatal("Expected an error for a directory without git repository")
+	}
+	test.AssertOutput(t, dir+" is not a git repository", err.Error())
+}
+
+func TestFindResourceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		given     string
+		resources map[string]v1alpha1.PipelineResourceType
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "single git resource",
+			resources: map[string]v1alpha1.PipelineResourceType{"source": "git", "image": "image"},
+			want:      "source",
+		},
+		{
+			name:      "named git resource",
+			given:     "docs",
+			resources: map[string]v1alpha1.PipelineResourceType{"source": "git", "docs": "git"},
+			want:      "docs",
+		},
+		{
+			name:      "more than one git resource",
+			resources: map[string]v1alpha1.PipelineResourceType{"source": "git", "docs": "git"},
+			wantErr:   "more than one git resource is declared, specify one with --git-from-local=<name>",
+		},
+		{
+			name:      "no git resource",
+			resources: map[string]v1alpha1.PipelineResourceType{"image": "image"},
+			wantErr:   "no git resource is declared",
+		},
+		{
+			name:      "named resource is not git",
+			given:     "image",
+			resources: map[string]v1alpha1.PipelineResourceType{"image": "image"},
+			wantErr:   "resource image is of type image, not git",
+		},
+		{
+			name:      "named resource is not declared",
+			given:     "foo",
+			resources: map[string]v1alpha1.PipelineResourceType{"image": "image"},
+			wantErr:   "resource foo is not declared",
+		},
+	}
+
+	for _, tp := range tests {
+		t.Run(tp.name, func(t *testing.T) {
+			got, err := FindResourceName(tp.given, tp.resources)
+			if tp.wantErr != "" {
+				if err == nil {
+					t.Fatalf("Expected error %q", tp.wantErr)
+				}
+				test.AssertOutput(t, tp.wantErr, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, got)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoker
+
+import (
+	"os"
+	"os/user"
+
+	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/cmd/version"
+	"github.com/tektoncd/cli/pkg/helper/git"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations recorded on the runs started by tkn
+const (
+	KubeUserAnnotation  = "cli.tekton.dev/kube-user"
+	OSUserAnnotation    = "cli.tekton.dev/os-user"
+	HostAnnotation      = "cli.tekton.dev/host"
+	VersionAnnotation   = "cli.tekton.dev/version"
+	GitCommitAnnotation = "cli.tekton.dev/git-commit"
+)
+
+var (
+	osUser = func() string {
+		if u, err := user.Current(); err == nil {
+			return u.Username
+		}
+		return os.Getenv("USER")
+	}
+	hostname = func() string {
+		h, _ := os.Hostname()
+		return h
+	}
+	gitCommit = func() string {
+		repo, err := git.Local(".")
+		if err != nil {
+			return ""
+		}
+		rev, _ := repo.Revision()
+		return rev
+	}
+)
+
+// Invoker describes who started a run and from where
+type Invoker struct {
+	KubeUser  string
+	OSUser    string
+	Host      string
+	Version   string
+	GitCommit string
+}
+
+// Current returns the invoker of the running tkn command
+func Current(p cli.Params) *Invoker {
+	return &Invoker{
+		KubeUser:  p.KubeUser(),
+		OSUser:    osUser(),
+		Host:      hostname(),
+		Version:   version.ClientVersion(),
+		GitCommit: gitCommit(),
+	}
+}
+
+// Annotate records the invoker in the annotations of meta, leaving out
+// the values which are unknown
+func (i *Invoker) Annotate(meta *metav1.ObjectMeta) {
+	for k, v := range map[string]string{
+		KubeUserAnnotation:  i.KubeUser,
+		OSUserAnnotation:    i.OSUser,
+		HostAnnotation:      i.Host,
+		VersionAnnotation:   i.Version,
+		GitCommitAnnotation: i.GitCommit,
+	} {
+		if v == "" {
+			continue
+		}
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		meta.Annotations[k] = v
+	}
+}
+
+// StartedBy returns who started the run, the kubeconfig user if known
+// and the local user otherwise
+func StartedBy(meta metav1.ObjectMeta) string {
+	if u := meta.Annotations[KubeUserAnnotation]; u != "" {
+		return u
+	}
+	return meta.Annotations[OSUserAnnotation]
+}
+
+// IsStartedBy reports whether the run was started by name, either as
+// kubeconfig user or as local user
+func IsStartedBy(meta metav1.ObjectMeta, name string) bool {
+	return meta.Annotations[KubeUserAnnotation] == name ||
+		meta.Annotations[OSUserAnnotation] == name
+}
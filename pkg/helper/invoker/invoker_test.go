@@ -0,0 +1,65 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoker
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnnotate(t *testing.T) {
+	i := &Invoker{
+		KubeUser: "kube-admin",
+		OSUser:   "jane",
+		Host:     "laptop",
+		Version:  "dev",
+	}
+
+	meta := metav1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}}
+	i.Annotate(&meta)
+
+	test.AssertOutput(t, map[string]string{
+		"foo":              "bar",
+		KubeUserAnnotation: "kube-admin",
+		OSUserAnnotation:   "jane",
+		HostAnnotation:     "laptop",
+		VersionAnnotation:  "dev",
+	}, meta.Annotations)
+
+	test.AssertOutput(t, "kube-admin", StartedBy(meta))
+	test.AssertOutput(t, true, IsStartedBy(meta, "jane"))
+	test.AssertOutput(t, false, IsStartedBy(meta, "laptop"))
+}
+
+func TestCurrent(t *testing.T) {
+	defer func(u, h, g func() string) { osUser, hostname, gitCommit = u, h, g }(osUser, hostname, gitCommit)
+	osUser = func() string { return "jane" }
+	hostname = func() string { return "laptop" }
+	gitCommit = func() string { return "" }
+
+	i := Current(&test.Params{User: "kube-admin"})
+	test.AssertOutput(t, &Invoker{
+		KubeUser: "kube-admin",
+		OSUser:   "jane",
+		Host:     "laptop",
+		Version:  "dev",
+	}, i)
+
+	meta := metav1.ObjectMeta{}
+	(&Invoker{OSUser: "jane"}).Annotate(&meta)
+	test.AssertOutput(t, "jane", StartedBy(meta))
+}
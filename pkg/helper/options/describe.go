@@ -0,0 +1,123 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// DescribeOptions are the options shared by the describe commands
+type DescribeOptions struct {
+	PrintFlags   *cliopts.PrintFlags
+	TemplateFile string
+	// Last describes the most recent run, of PipelineName or TaskName when
+	// they are set
+	Last         bool
+	PipelineName string
+	TaskName     string
+	// Limit is the number of runs to select from when no name is given
+	Limit int
+	// Interactive tells if the run to describe can be selected with a
+	// prompt when no name is given
+	Interactive bool
+	AskOpts     survey.AskOpt
+}
+
+// NewDescribeOptions returns the options of a describe command, the run to
+// describe is asked for when stdin is a terminal
+func NewDescribeOptions() *DescribeOptions {
+	return &DescribeOptions{
+		PrintFlags:  cliopts.NewPrintFlags("describe"),
+		Limit:       5,
+		Interactive: isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd()),
+		AskOpts: func(opt *survey.AskOptions) error {
+			opt.Stdio = terminal.Stdio{
+				In:  os.Stdin,
+				Out: os.Stdout,
+				Err: os.Stderr,
+			}
+			return nil
+		},
+	}
+}
+
+// AddFlags adds -o and --template-file to the describe command c
+func (o *DescribeOptions) AddFlags(c *cobra.Command) {
+	o.PrintFlags.AddFlags(c)
+	c.Flags().StringVarP(&o.TemplateFile, "template-file", "", "", "path of a go template to print the description with, it gets the same data and functions as the default template")
+	_ = c.MarkFlagFilename("template-file")
+}
+
+// Output returns the format set with -o, the description is printed with the
+// template when it is empty
+func (o *DescribeOptions) Output() string {
+	if o.PrintFlags == nil || o.PrintFlags.OutputFormat == nil {
+		return ""
+	}
+	return *o.PrintFlags.OutputFormat
+}
+
+// Template returns the template of the description: the one read from the
+// file set with --template-file or the default one
+func (o *DescribeOptions) Template(name, defaultTemplate string, funcs template.FuncMap) (*template.Template, error) {
+	text := defaultTemplate
+	if o.TemplateFile != "" {
+		b, err := ioutil.ReadFile(o.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file %s: %s", o.TemplateFile, err)
+		}
+		text = string(b)
+	}
+
+	t, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil && o.TemplateFile != "" {
+		return nil, fmt.Errorf("failed to parse template file %s: %s", o.TemplateFile, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the default template: %s", err)
+	}
+	return t, nil
+}
+
+// Ask prompts for the resource to describe among options, which start with
+// the name of the resource
+func (o *DescribeOptions) Ask(resource string, options []string) (string, error) {
+	var ans string
+	var qs = []*survey.Question{
+		{
+			Name: resource,
+			Prompt: &survey.Select{
+				Message: fmt.Sprintf("Select %s:", resource),
+				Options: options,
+			},
+		},
+	}
+
+	if err := survey.Ask(qs, &ans, o.AskOpts); err != nil {
+		return "", err
+	}
+
+	return strings.Fields(ans)[0], nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/tektoncd/cli/pkg/test"
+)
+
+func TestDescribeOptions_Template(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tkn-describe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "describe.tmpl")
+	if err := ioutil.WriteFile(file, []byte(`{{ upper .Name }} from file`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+	data := struct{ Name string }{Name: "build"}
+
+	testParams := []struct {
+		name      string
+		opts      *DescribeOptions
+		wantError bool
+		want      string
+	}{
+		{
+			name: "Default template",
+			opts: NewDescribeOptions(),
+			want: "BUILD",
+		},
+		{
+			name: "Template file",
+			opts: &DescribeOptions{TemplateFile: file},
+			want: "BUILD from file",
+		},
+		{
+			name:      "Missing template file",
+			opts:      &DescribeOptions{TemplateFile: filepath.Join(dir, "missing.tmpl")},
+			wantError: true,
+			want:      "failed to read template file " + filepath.Join(dir, "missing.tmpl") + ": open " + filepath.Join(dir, "missing.tmpl") + ": no such file or directory",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			tmpl, err := tp.opts.Template("test", `{{ upper .Name }}`, funcs)
+			if tp.wantError {
+				if err == nil {
+					t.Fatal("error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := tmpl.Execute(&out, data); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			test.AssertOutput(t, tp.want, out.String())
+		})
+	}
+
+	// the template file is only named when it is the one failing to parse
+	_, err = NewDescribeOptions().Template("test", `{{ upper .Name }`, funcs)
+	if err == nil {
+		t.Fatal("error expected here")
+	}
+	test.AssertOutput(t, `failed to parse the default template: template: test:1: unexpected "}" in operand`, err.Error())
+
+	if err := ioutil.WriteFile(file, []byte(`{{ upper .Name }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&DescribeOptions{TemplateFile: file}).Template("test", `{{ upper .Name }}`, funcs)
+	if err == nil {
+		t.Fatal("error expected here")
+	}
+	test.AssertOutput(t, "failed to parse template file "+file+`: template: test:1: unexpected "}" in operand`, err.Error())
+}
+
+func TestDescribeOptions_Output(t *testing.T) {
+	opts := NewDescribeOptions()
+	test.AssertOutput(t, "", opts.Output())
+
+	*opts.PrintFlags.OutputFormat = "yaml"
+	test.AssertOutput(t, "yaml", opts.Output())
+}
@@ -17,12 +17,18 @@ package options
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/tektoncd/cli/pkg/cli"
+	"github.com/tektoncd/cli/pkg/helper/pods"
 	"github.com/tektoncd/cli/pkg/helper/pods/stream"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis/duck/v1beta1"
 )
 
 const (
@@ -30,8 +36,18 @@ const (
 	ResourceNamePipelineRun = "pipelinerun"
 	ResourceNameTask        = "task"
 	ResourceNameTaskRun     = "taskrun"
+
+	// OutputJSON writes the logs as JSON lines
+	OutputJSON = "json"
+
+	// AllAttempts selects all the attempts of a TaskRun with retries
+	AllAttempts = -1
 )
 
+// RunStatuses are the statuses by which the runs searched with --grep can
+// be filtered
+var RunStatuses = []string{"succeeded", "failed", "running", "cancelled"}
+
 type LogOptions struct {
 	AllSteps        bool
 	Follow          bool
@@ -43,8 +59,23 @@ type LogOptions struct {
 	Stream          *cli.Stream
 	Streamer        stream.NewStreamerFunc
 	Tasks           []string
+	Steps           []string
+	Sidecars        bool
+	Attempt         string
 	Last            bool
 	Limit           int
+	Timestamps      bool
+	Since           time.Duration
+	SinceTime       string
+	Tail            int64
+	Output          string
+	OutputDir       string
+	Archive         string
+	Grep            string
+	Runs            int
+	Status          string
+	Parallel        int
+	Interleave      bool
 	AskOpts         survey.AskOpt
 }
 
@@ -68,6 +99,126 @@ func (opts *LogOptions) ValidateOpts() error {
 	return nil
 }
 
+// PodLogOptions returns the options restricting the logs read from each
+// container of the run
+func (opts *LogOptions) PodLogOptions() (pods.LogOptions, error) {
+	lo := pods.LogOptions{
+		// the JSON records always carry the timestamp of the line, which
+		// is also needed to interleave the logs of the tasks
+		Timestamps: opts.Timestamps || opts.Output == OutputJSON || opts.Interleave,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
+	}
+
+	if opts.Since < 0 {
+		return lo, fmt.Errorf("since was %s but must be a positive duration", opts.Since)
+	}
+
+	if opts.Tail < 0 {
+		return lo, fmt.Errorf("tail was %d but must be a positive number", opts.Tail)
+	}
+
+	if opts.SinceTime != "" {
+		if opts.Since != 0 {
+			return lo, fmt.Errorf("only one of --since and --since-time can be used")
+		}
+
+		t, err := time.Parse(time.RFC3339, opts.SinceTime)
+		if err != nil {
+			return lo, fmt.Errorf("since-time %q is not a RFC3339 date: %s", opts.SinceTime, err)
+		}
+		lo.SinceTime = t
+	}
+
+	return lo, nil
+}
+
+// JSONOutput tells whether the logs are written as JSON lines rather than
+// as text
+func (opts *LogOptions) JSONOutput() (bool, error) {
+	switch opts.Output {
+	case "":
+		return false, nil
+	case OutputJSON:
+		return true, nil
+	}
+	return false, fmt.Errorf("output format %q is not supported, use %s", opts.Output, OutputJSON)
+}
+
+// AttemptNumber returns the attempt of the TaskRuns whose logs are shown,
+// 0 for the latest one or AllAttempts
+func (opts *LogOptions) AttemptNumber() (int, error) {
+	switch opts.Attempt {
+	case "":
+		return 0, nil
+	case "all":
+		return AllAttempts, nil
+	}
+
+	n, err := strconv.Atoi(opts.Attempt)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("attempt was %q but must be a positive number or all", opts.Attempt)
+	}
+	return n, nil
+}
+
+// GrepPattern returns the pattern searched in the logs of the runs, nil
+// when the logs are not searched
+func (opts *LogOptions) GrepPattern() (*regexp.Regexp, error) {
+	if opts.Grep == "" {
+		return nil, nil
+	}
+
+	if opts.Runs <= 0 {
+		return nil, fmt.Errorf("runs was %d but must be a positive number", opts.Runs)
+	}
+
+	if opts.Parallel <= 0 {
+		return nil, fmt.Errorf("parallel was %d but must be a positive number", opts.Parallel)
+	}
+
+	if opts.Status != "" && !contains(RunStatuses, opts.Status) {
+		return nil, fmt.Errorf("status was %q but must be one of %s", opts.Status, strings.Join(RunStatuses, ", "))
+	}
+
+	re, err := regexp.Compile(opts.Grep)
+	if err != nil {
+		return nil, fmt.Errorf("grep pattern %q is not valid: %s", opts.Grep, err)
+	}
+	return re, nil
+}
+
+// HasStatus tells whether a run with these conditions has the status
+// searched runs are filtered by
+func (opts *LogOptions) HasStatus(c v1beta1.Conditions) bool {
+	if opts.Status == "" {
+		return true
+	}
+
+	if len(c) == 0 {
+		return opts.Status == "running"
+	}
+
+	switch {
+	case strings.HasSuffix(c[0].Reason, "Cancelled"):
+		return opts.Status == "cancelled"
+	case c[0].Status == corev1.ConditionTrue:
+		return opts.Status == "succeeded"
+	case c[0].Status == corev1.ConditionFalse:
+		return opts.Status == "failed"
+	}
+	return opts.Status == "running"
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
 func (opts *LogOptions) Ask(resource string, options []string) error {
 	var ans string
 	var qs = []*survey.Question{
@@ -64,6 +64,64 @@ func TestLogOptions_ValidateOpts(t *testing.T) {
 	}
 }
 
+func TestLogOptions_GrepPattern(t *testing.T) {
+
+	testParams := []struct {
+		name      string
+		opts      LogOptions
+		wantError bool
+		want      string
+	}{
+		{
+			name:      "valid search",
+			opts:      LogOptions{Grep: "error: .*", Runs: 10, Parallel: 4, Status: "failed"},
+			wantError: false,
+			want:      "error: .*",
+		},
+		{
+			name:      "invalid pattern",
+			opts:      LogOptions{Grep: "error: (", Runs: 10, Parallel: 4},
+			wantError: true,
+			want:      "grep pattern \"error: (\" is not valid: error parsing regexp: missing closing ): `error: (`",
+		},
+		{
+			name:      "invalid runs",
+			opts:      LogOptions{Grep: "error", Runs: 0, Parallel: 4},
+			wantError: true,
+			want:      "runs was 0 but must be a positive number",
+		},
+		{
+			name:      "invalid parallel",
+			opts:      LogOptions{Grep: "error", Runs: 10, Parallel: -1},
+			wantError: true,
+			want:      "parallel was -1 but must be a positive number",
+		},
+		{
+			name:      "invalid status",
+			opts:      LogOptions{Grep: "error", Runs: 10, Parallel: 4, Status: "done"},
+			wantError: true,
+			want:      "status was \"done\" but must be one of succeeded, failed, running, cancelled",
+		},
+	}
+
+	for _, tp := range testParams {
+		t.Run(tp.name, func(t *testing.T) {
+			re, err := tp.opts.GrepPattern()
+			if tp.wantError {
+				if err == nil {
+					t.Fatalf("Error expected here")
+				}
+				test.AssertOutput(t, tp.want, err.Error())
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected Error")
+				}
+				test.AssertOutput(t, tp.want, re.String())
+			}
+		})
+	}
+}
+
 func TestLogOptions_Ask(t *testing.T) {
 
 	options := []string{
@@ -37,6 +37,9 @@ func LastRun(tekton versioned.Interface, pipeline string, ns string) (*v1alpha1.
 	}
 
 	if len(runs.Items) == 0 {
+		if pipeline == "" {
+			return nil, fmt.Errorf("no pipelineruns found in namespace %s", ns)
+		}
 		return nil, fmt.Errorf("no pipelineruns related to pipeline %s found in namespace %s", pipeline, ns)
 	}
 
@@ -15,6 +15,8 @@
 package pipelinerun
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	trh "github.com/tektoncd/cli/pkg/helper/taskrun"
@@ -48,8 +50,10 @@ func NewTracker(name string, ns string, tekton versioned.Interface) *Tracker {
 //Monitor to observe the progress of PipelineRun. It emits
 //an event upon starting of a new Pipeline's Task.
 //allowed containers the name of the Pipeline tasks, which used as filter
-//limit the events to only those tasks
-func (t *Tracker) Monitor(allowed []string) <-chan []trh.Run {
+//limit the events to only those tasks. The channel is closed and the
+//informer stopped once the PipelineRun completes or ctx is done.
+func (t *Tracker) Monitor(ctx context.Context, allowed []string) <-chan []trh.Run {
+	ctx, cancel := context.WithCancel(ctx)
 
 	factory := informers.NewSharedInformerFactoryWithOptions(
 		t.Tekton,
@@ -59,11 +63,19 @@ func (t *Tracker) Monitor(allowed []string) <-chan []trh.Run {
 
 	informer := factory.Tekton().V1alpha1().PipelineRuns().Informer()
 
-	stopC := make(chan struct{})
 	trC := make(chan []trh.Run)
 
+	// mu guards trC so that it isn't closed while an event is being sent
+	var (
+		mu     sync.Mutex
+		closed bool
+	)
+
 	go func() {
-		<-stopC
+		<-ctx.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
 		close(trC)
 	}()
 
@@ -73,10 +85,20 @@ func (t *Tracker) Monitor(allowed []string) <-chan []trh.Run {
 			return
 		}
 
-		trC <- t.findNewTaskruns(pr, allowed)
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+
+		select {
+		case trC <- t.findNewTaskruns(pr, allowed):
+		case <-ctx.Done():
+			return
+		}
 
 		if hasCompleted(pr) {
-			close(stopC) // should close trC
+			cancel() // should close trC
 		}
 	}
 
@@ -87,8 +109,8 @@ func (t *Tracker) Monitor(allowed []string) <-chan []trh.Run {
 		},
 	)
 
-	factory.Start(stopC)
-	factory.WaitForCacheSync(stopC)
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
 
 	return trC
 }
@@ -107,6 +129,19 @@ func pipelinerunOpts(name string) func(opts *metav1.ListOptions) {
 func (t *Tracker) findNewTaskruns(pr *v1alpha1.PipelineRun, allowed []string) []trh.Run {
 	ret := []trh.Run{}
 	for tr, trs := range pr.Status.TaskRuns {
+		for cc, ccs := range trs.ConditionChecks {
+			run := trh.Run{Name: cc, Task: trs.PipelineTaskName, Condition: ccs.ConditionName}
+
+			if t.loggingInProgress(cc) ||
+				!trh.HasConditionCheckScheduled(ccs) ||
+				trh.IsFiltered(run, allowed) {
+				continue
+			}
+
+			t.ongoingTasks[cc] = true
+			ret = append(ret, run)
+		}
+
 		run := trh.Run{Name: tr, Task: trs.PipelineTaskName}
 
 		if t.loggingInProgress(tr) ||
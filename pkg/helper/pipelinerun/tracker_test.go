@@ -15,6 +15,7 @@
 package pipelinerun
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -143,7 +144,7 @@ func TestTracker_pipelinerun_complete(t *testing.T) {
 
 func taskRunsFor(onlyTasks []string, tracker *Tracker) []trh.Run {
 	output := []trh.Run{}
-	for ts := range tracker.Monitor(onlyTasks) {
+	for ts := range tracker.Monitor(context.Background(), onlyTasks) {
 		output = append(output, ts...)
 	}
 	return output
@@ -166,3 +167,113 @@ func startPipelineRun(t *testing.T, data pipelinetest.Data, prStatus ...v1alpha1
 
 	return cs.Pipeline
 }
+
+func TestTracker_condition_checks(t *testing.T) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+
+		taskName = "output-task"
+		trName   = "output-task-1"
+		ccName   = "output-task-1-file-exists"
+	)
+
+	taskruns := []*v1alpha1.TaskRun{
+		tb.TaskRun(trName, ns,
+			tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskName),
+			),
+			tb.TaskRunStatus(
+				tb.PodName("output-task-1-pod"),
+			),
+		),
+	}
+
+	checks := map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+		ccName: {
+			ConditionName: "file-exists",
+			Status:        &v1alpha1.ConditionCheckStatus{PodName: "output-task-1-file-exists-pod"},
+		},
+	}
+
+	initialPR := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", prName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Status: corev1.ConditionUnknown,
+					Reason: resources.ReasonRunning,
+				}),
+				tb.PipelineRunTaskRunsStatus(trName, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: taskName,
+					ConditionChecks:  checks,
+				}),
+			),
+		),
+	}
+
+	pr := &v1alpha1.PipelineRun{}
+	tb.PipelineRunStatus(
+		tb.PipelineRunStatusCondition(apis.Condition{
+			Status: corev1.ConditionTrue,
+			Reason: resources.ReasonSucceeded,
+		}),
+		tb.PipelineRunTaskRunsStatus(trName, &v1alpha1.PipelineRunTaskRunStatus{
+			PipelineTaskName: taskName,
+			ConditionChecks:  checks,
+			Status:           &taskruns[0].Status,
+		}),
+	)(pr)
+
+	tc := startPipelineRun(t, pipelinetest.Data{PipelineRuns: initialPR, TaskRuns: taskruns}, pr.Status)
+	tracker := NewTracker(pipelineName, ns, tc)
+	output := taskRunsFor([]string{}, tracker)
+
+	expected := []trh.Run{
+		{Name: ccName, Task: taskName, Condition: "file-exists"},
+		{Name: trName, Task: taskName},
+	}
+	clitest.AssertOutput(t, expected, output)
+}
+
+func TestTracker_cancel(t *testing.T) {
+	var (
+		pipelineName = "output-pipeline"
+		prName       = "output-pipeline-1"
+		ns           = "namespace"
+	)
+
+	initialPR := []*v1alpha1.PipelineRun{
+		tb.PipelineRun(prName, ns,
+			tb.PipelineRunLabel("tekton.dev/pipeline", prName),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Status: corev1.ConditionUnknown,
+					Reason: resources.ReasonRunning,
+				}),
+			),
+		),
+	}
+
+	tc := startPipelineRun(t, pipelinetest.Data{PipelineRuns: initialPR})
+	tracker := NewTracker(pipelineName, ns, tc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	trC := tracker.Monitor(ctx, []string{})
+	cancel()
+
+	// the channel of a running PipelineRun is closed once ctx is done
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range trC {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the channel to be closed once the context is cancelled")
+	}
+}
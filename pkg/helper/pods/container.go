@@ -16,11 +16,18 @@ package pods
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"strings"
+	"time"
 
 	"github.com/tektoncd/cli/pkg/helper/pods/stream"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type Container struct {
@@ -69,28 +76,99 @@ func (c *Container) Status() error {
 	return nil
 }
 
+// Terminated returns the state of the container once it has terminated,
+// nil while it is still waiting or running
+func (c *Container) Terminated() (*corev1.ContainerStateTerminated, error) {
+	pod, err := c.pod.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.Name == c.name {
+			return cs.State.Terminated, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // Log represents one log message from a pod
 type Log struct {
 	PodName       string
 	ContainerName string
+	Timestamp     time.Time
 	Log           string
 }
+
+// LogOptions restricts the logs read from a container and asks for the
+// timestamp of each line
+type LogOptions struct {
+	Timestamps bool
+	Since      time.Duration
+	SinceTime  time.Time
+	Tail       int64
+}
+
+// PodLogOptions returns the options to stream the logs of container
+func (o LogOptions) PodLogOptions(container string, follow bool) *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{
+		Follow:     follow,
+		Container:  container,
+		Timestamps: o.Timestamps,
+	}
+
+	if o.Since > 0 {
+		seconds := int64(o.Since.Round(time.Second) / time.Second)
+		if seconds == 0 {
+			seconds = 1
+		}
+		opts.SinceSeconds = &seconds
+	}
+
+	if !o.SinceTime.IsZero() {
+		t := metav1.NewTime(o.SinceTime)
+		opts.SinceTime = &t
+	}
+
+	if o.Tail > 0 {
+		tail := o.Tail
+		opts.TailLines = &tail
+	}
+
+	return opts
+}
+
+// streamBackoff is how long the stream of the logs of a running container
+// is retried after the connection is lost
+var streamBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    6,
+}
+
 type LogReader struct {
 	containerName string
 	pod           *Pod
 	follow        bool
+	opts          LogOptions
 }
 
-func (c *Container) LogReader(follow bool) *LogReader {
-	return &LogReader{c.name, c.pod, follow}
+func (c *Container) LogReader(follow bool, opts LogOptions) *LogReader {
+	return &LogReader{c.name, c.pod, follow, opts}
 }
 
-func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
+// Read streams the logs of the container until ctx is done. When following
+// the logs, the stream is resumed after the connection is lost from the
+// timestamp of the last line read, skipping the lines already read
+func (lr *LogReader) Read(ctx context.Context) (<-chan Log, <-chan error, error) {
 	pod := lr.pod
-	opts := &corev1.PodLogOptions{
-		Follow:    lr.follow,
-		Container: lr.containerName,
-	}
+	opts := lr.opts.PodLogOptions(lr.containerName, lr.follow)
+	// the timestamps allow to resume the stream where it stopped
+	opts.Timestamps = opts.Timestamps || lr.follow
 
 	stream, err := pod.Stream(opts)
 	if err != nil {
@@ -103,26 +181,221 @@ func (lr *LogReader) Read() (<-chan Log, <-chan error, error) {
 	go func() {
 		defer close(logC)
 		defer close(errC)
-		defer stream.Close()
 
-		r := bufio.NewReader(stream)
+		var (
+			resume  = &resumeState{}
+			delay   = streamBackoff.Duration
+			steps   = 0
+			openErr error
+		)
+
+		sendError := func(err error) {
+			select {
+			case errC <- err:
+			case <-ctx.Done():
+			}
+		}
+
 		for {
-			line, _, err := r.ReadLine()
+			n, err := lr.readStream(ctx, stream, resume, logC)
+			stream.Close()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !lr.follow {
+				if err != nil {
+					sendError(err)
+				}
+				return
+			}
+
+			// the stream of a terminated container ends once all its
+			// logs are read
+			running, serr := lr.running()
+			if serr != nil {
+				sendError(serr)
+				return
+			}
+			if err == nil && !running {
+				return
+			}
 
-			if err != nil {
-				if err != io.EOF {
-					errC <- err
+			if n > 0 {
+				delay, steps = streamBackoff.Duration, 0
+			}
+			if steps == streamBackoff.Steps {
+				if err == nil {
+					err = openErr
+				}
+				if err == nil {
+					err = io.ErrUnexpectedEOF
 				}
+				sendError(fmt.Errorf("lost the connection to the logs of pod %s(%s) : %s", pod.Name, lr.containerName, err))
+				return
+			}
+
+			select {
+			case <-time.After(wait.Jitter(delay, streamBackoff.Jitter)):
+			case <-ctx.Done():
 				return
 			}
+			delay = time.Duration(float64(delay) * streamBackoff.Factor)
+			steps++
 
-			logC <- Log{
-				PodName:       pod.Name,
-				ContainerName: lr.containerName,
-				Log:           string(line),
+			opts := lr.opts.PodLogOptions(lr.containerName, lr.follow)
+			opts.Timestamps = true
+			resume.apply(opts)
+			if stream, openErr = pod.Stream(opts); openErr != nil {
+				// the connection may not be back yet, the next stream
+				// is then empty
+				stream = ioutil.NopCloser(strings.NewReader(""))
 			}
 		}
 	}()
 
 	return logC, errC, nil
 }
+
+// readStream sends the lines of the stream which have not been read
+// before, it returns how many lines were sent and the error which stopped
+// the stream, nil when it ended. The stream is closed once ctx is done to
+// interrupt the read in progress.
+func (lr *LogReader) readStream(ctx context.Context, stream io.ReadCloser, resume *resumeState, logC chan<- Log) (int, error) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+
+	n := 0
+	r := bufio.NewReader(stream)
+	for {
+		line, _, err := r.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+
+		l := Log{
+			PodName:       lr.pod.Name,
+			ContainerName: lr.containerName,
+			Log:           string(line),
+		}
+		if lr.opts.Timestamps || lr.follow {
+			l.Timestamp, l.Log = splitTimestamp(l.Log)
+		}
+
+		if resume.seen(l.Timestamp) {
+			continue
+		}
+
+		if !lr.opts.Timestamps {
+			l.Timestamp = time.Time{}
+		}
+
+		select {
+		case logC <- l:
+			n++
+		case <-ctx.Done():
+			return n, ctx.Err()
+		}
+	}
+}
+
+// running tells whether the container is still running, it fails when
+// the pod has been deleted
+func (lr *LogReader) running() (bool, error) {
+	pod, err := lr.pod.Get()
+	if k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("pod %s has been deleted while streaming the logs of %s", lr.pod.Name, lr.containerName)
+	}
+	if err != nil {
+		// the API server may be unavailable for a while
+		return true, nil
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return false, fmt.Errorf("pod %s is being deleted while streaming the logs of %s", lr.pod.Name, lr.containerName)
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.Name == lr.containerName {
+			return cs.State.Running != nil, nil
+		}
+	}
+	return false, nil
+}
+
+// resumeState remembers the timestamp of the last line read to skip the
+// lines read again when the stream is resumed
+type resumeState struct {
+	last time.Time
+	// count is the number of lines read with the timestamp last
+	count int
+	// skip is the number of lines with the timestamp last still to skip
+	skip int
+}
+
+// apply requests the logs from the last line read, the API server sending
+// them from the start of its second
+func (r *resumeState) apply(opts *corev1.PodLogOptions) {
+	if r.last.IsZero() {
+		return
+	}
+
+	t := metav1.NewTime(r.last)
+	opts.SinceTime = &t
+	opts.SinceSeconds = nil
+	opts.TailLines = nil
+	r.skip = r.count
+}
+
+// seen tells whether the line with timestamp ts has already been read
+func (r *resumeState) seen(ts time.Time) bool {
+	if ts.IsZero() {
+		return false
+	}
+
+	switch {
+	case ts.Before(r.last):
+		return true
+	case ts.Equal(r.last):
+		if r.skip > 0 {
+			r.skip--
+			return true
+		}
+		r.count++
+	default:
+		r.last, r.count, r.skip = ts, 1, 0
+	}
+	return false
+}
+
+// splitTimestamp separates the RFC3339 timestamp the API server prefixes
+// each line with when timestamps are requested
+func splitTimestamp(line string) (time.Time, string) {
+	i := strings.Index(line, " ")
+	if i < 0 {
+		i = len(line)
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, line[:i])
+	if err != nil {
+		return time.Time{}, line
+	}
+
+	if i == len(line) {
+		return ts, ""
+	}
+	return ts, line[i+1:]
+}
@@ -15,13 +15,22 @@
 package pods
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/cli/pkg/helper/pods/fake"
+	"github.com/tektoncd/cli/pkg/helper/pods/stream"
 	"github.com/tektoncd/cli/pkg/test"
 	pipelinetest "github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 func TestContainer_fetch_logs(t *testing.T) {
@@ -29,12 +38,14 @@ func TestContainer_fetch_logs(t *testing.T) {
 	ns := "test"
 	container1 := "step-build-app"
 	container2 := "nop"
+	container3 := "step-test-app"
 
 	ps := []*corev1.Pod{
 		tb.Pod(podName, ns,
 			tb.PodSpec(
 				tb.PodContainer(container1, "step-build-app:latest"),
 				tb.PodContainer(container2, "override-with-nop:latest"),
+				tb.PodContainer(container3, "step-test-app:latest"),
 			),
 		),
 	}
@@ -43,6 +54,10 @@ func TestContainer_fetch_logs(t *testing.T) {
 		fake.PodLog(podName,
 			fake.NewContainer(container1, "pushed blob sha256:7be8c1df53f934d63b71db8595212e2955fd30a9b0054eccf42d732f53ef136b"),
 			fake.NewContainer(container2, "Task completed successfully"),
+			fake.NewContainer(container3,
+				"2019-12-02T10:00:00.123456789Z running tests",
+				"2019-12-02T10:00:01Z tests passed",
+			),
 		),
 	)
 
@@ -53,6 +68,7 @@ func TestContainer_fetch_logs(t *testing.T) {
 	type testdata struct {
 		container string
 		follow    bool
+		opts      LogOptions
 		expected  []Log
 	}
 
@@ -75,10 +91,36 @@ func TestContainer_fetch_logs(t *testing.T) {
 				Log:           "Task completed successfully",
 			}},
 		},
+
+		{
+			container: container3, follow: false,
+			opts: LogOptions{Timestamps: true},
+			expected: []Log{{
+				PodName:       podName,
+				ContainerName: container3,
+				Timestamp:     time.Date(2019, 12, 2, 10, 0, 0, 123456789, time.UTC),
+				Log:           "running tests",
+			}, {
+				PodName:       podName,
+				ContainerName: container3,
+				Timestamp:     time.Date(2019, 12, 2, 10, 0, 1, 0, time.UTC),
+				Log:           "tests passed",
+			}},
+		},
+
+		{
+			container: container3, follow: false,
+			opts: LogOptions{Tail: 1},
+			expected: []Log{{
+				PodName:       podName,
+				ContainerName: container3,
+				Log:           "2019-12-02T10:00:01Z tests passed",
+			}},
+		},
 	}
 
 	for _, d := range td {
-		lr := pod.Container(d.container).LogReader(d.follow)
+		lr := pod.Container(d.container).LogReader(d.follow, d.opts)
 		output, err := containerLogs(lr)
 
 		if err != nil {
@@ -89,8 +131,30 @@ func TestContainer_fetch_logs(t *testing.T) {
 	}
 }
 
+func TestLogOptions_PodLogOptions(t *testing.T) {
+	since := time.Date(2019, 12, 2, 10, 0, 0, 0, time.UTC)
+	opts := LogOptions{
+		Timestamps: true,
+		Since:      10 * time.Minute,
+		SinceTime:  since,
+		Tail:       20,
+	}.PodLogOptions("step-build", true)
+
+	test.AssertOutput(t, "step-build", opts.Container)
+	test.AssertOutput(t, true, opts.Follow)
+	test.AssertOutput(t, true, opts.Timestamps)
+	test.AssertOutput(t, int64(600), *opts.SinceSeconds)
+	test.AssertOutput(t, since, opts.SinceTime.Time)
+	test.AssertOutput(t, int64(20), *opts.TailLines)
+
+	opts = LogOptions{}.PodLogOptions("step-build", false)
+	if opts.SinceSeconds != nil || opts.SinceTime != nil || opts.TailLines != nil {
+		t.Errorf("Expected no restriction on the logs, got %v", opts)
+	}
+}
+
 func containerLogs(lr *LogReader) ([]Log, error) {
-	logC, errC, err := lr.Read()
+	logC, errC, err := lr.Read(context.Background())
 
 	output := []Log{}
 	if err != nil {
@@ -112,3 +176,229 @@ func containerLogs(lr *LogReader) ([]Log, error) {
 		}
 	}
 }
+
+type brokenStream struct {
+	data string
+	err  error
+}
+
+func (s *brokenStream) Read(p []byte) (int, error) {
+	if s.data == "" {
+		return 0, s.err
+	}
+	n := copy(p, s.data)
+	s.data = s.data[n:]
+	return n, nil
+}
+
+func (s *brokenStream) Close() error {
+	return nil
+}
+
+type streamFunc func() (io.ReadCloser, error)
+
+func (f streamFunc) Stream() (io.ReadCloser, error) {
+	return f()
+}
+
+func runningPod(name, ns, container string, running bool) *corev1.Pod {
+	state := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	if running {
+		state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: container, State: state},
+			},
+		},
+	}
+}
+
+func readAll(lr *LogReader) ([]string, []string, error) {
+	logC, errC, err := lr.Read(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logs, errs := []string{}, []string{}
+	for logC != nil || errC != nil {
+		select {
+		case l, ok := <-logC:
+			if !ok {
+				logC = nil
+				continue
+			}
+			logs = append(logs, l.Log)
+		case e, ok := <-errC:
+			if !ok {
+				errC = nil
+				continue
+			}
+			errs = append(errs, e.Error())
+		}
+	}
+	return logs, errs, nil
+}
+
+func TestLogReader_resume(t *testing.T) {
+	defer func(b wait.Backoff) { streamBackoff = b }(streamBackoff)
+	streamBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pods: []*corev1.Pod{runningPod("pod", "ns", "step", true)}})
+	pods := cs.Kube.CoreV1().Pods("ns")
+
+	since := []string{}
+	streams := []func() io.ReadCloser{
+		func() io.ReadCloser {
+			return &brokenStream{
+				data: "2019-12-02T10:00:01.1Z first\n2019-12-02T10:00:01.2Z second\n",
+				err:  errors.New("connection reset by peer"),
+			}
+		},
+		func() io.ReadCloser {
+			// the connection is not back yet
+			return nil
+		},
+		func() io.ReadCloser {
+			return &brokenStream{
+				data: "2019-12-02T10:00:01.1Z first\n2019-12-02T10:00:01.2Z second\n2019-12-02T10:00:02Z third\n",
+				err:  io.EOF,
+			}
+		},
+		func() io.ReadCloser {
+			// the container terminates
+			if _, err := pods.Update(runningPod("pod", "ns", "step", false)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			return &brokenStream{
+				data: "2019-12-02T10:00:02Z third\n2019-12-02T10:00:02Z fourth\n",
+				err:  io.EOF,
+			}
+		},
+	}
+
+	streamer := func(p typedv1.PodInterface, name string, opts *corev1.PodLogOptions) stream.Streamer {
+		return streamFunc(func() (io.ReadCloser, error) {
+			if opts.SinceTime != nil {
+				since = append(since, opts.SinceTime.UTC().Format(time.RFC3339Nano))
+			}
+			s := streams[0]()
+			streams = streams[1:]
+			if s == nil {
+				return nil, errors.New("connection refused")
+			}
+			return s, nil
+		})
+	}
+
+	pod := New("pod", "ns", cs.Kube, streamer)
+	logs, errs, err := readAll(pod.Container("step").LogReader(true, LogOptions{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, []string{"first", "second", "third", "fourth"}, logs)
+	test.AssertOutput(t, []string{}, errs)
+	test.AssertOutput(t, []string{
+		"2019-12-02T10:00:01.2Z",
+		"2019-12-02T10:00:01.2Z",
+		"2019-12-02T10:00:02Z",
+	}, since)
+}
+
+func TestLogReader_pod_deleted(t *testing.T) {
+	defer func(b wait.Backoff) { streamBackoff = b }(streamBackoff)
+	streamBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pods: []*corev1.Pod{runningPod("pod", "ns", "step", true)}})
+
+	streamer := func(p typedv1.PodInterface, name string, opts *corev1.PodLogOptions) stream.Streamer {
+		return streamFunc(func() (io.ReadCloser, error) {
+			if err := p.Delete(name, &metav1.DeleteOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			return &brokenStream{data: "2019-12-02T10:00:01Z started\n", err: io.ErrUnexpectedEOF}, nil
+		})
+	}
+
+	pod := New("pod", "ns", cs.Kube, streamer)
+	logs, errs, err := readAll(pod.Container("step").LogReader(true, LogOptions{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, []string{"started"}, logs)
+	test.AssertOutput(t, []string{"pod pod has been deleted while streaming the logs of step"}, errs)
+}
+
+func TestLogReader_connection_lost(t *testing.T) {
+	defer func(b wait.Backoff) { streamBackoff = b }(streamBackoff)
+	streamBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 2}
+
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pods: []*corev1.Pod{runningPod("pod", "ns", "step", true)}})
+
+	calls := 0
+	streamer := func(p typedv1.PodInterface, name string, opts *corev1.PodLogOptions) stream.Streamer {
+		return streamFunc(func() (io.ReadCloser, error) {
+			calls++
+			if calls > 1 {
+				return nil, errors.New("connection refused")
+			}
+			return &brokenStream{data: "2019-12-02T10:00:01Z started\n", err: errors.New("connection reset by peer")}, nil
+		})
+	}
+
+	pod := New("pod", "ns", cs.Kube, streamer)
+	logs, errs, err := readAll(pod.Container("step").LogReader(true, LogOptions{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	test.AssertOutput(t, []string{"started"}, logs)
+	test.AssertOutput(t, []string{"lost the connection to the logs of pod pod(step) : connection refused"}, errs)
+	test.AssertOutput(t, 3, calls)
+}
+
+func TestLogReader_cancel(t *testing.T) {
+	cs, _ := test.SeedTestData(t, pipelinetest.Data{Pods: []*corev1.Pod{runningPod("pod", "ns", "step", true)}})
+
+	// the stream blocks after the first line, as the one of a running
+	// container does
+	r, w := io.Pipe()
+	go fmt.Fprintln(w, "2019-12-02T10:00:01Z first")
+
+	streamer := func(p typedv1.PodInterface, name string, opts *corev1.PodLogOptions) stream.Streamer {
+		return streamFunc(func() (io.ReadCloser, error) {
+			return r, nil
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pod := New("pod", "ns", cs.Kube, streamer)
+	logC, errC, err := pod.Container("step").LogReader(true, LogOptions{}).Read(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	l := <-logC
+	test.AssertOutput(t, "first", l.Log)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range logC {
+		}
+		for range errC {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the channels to be closed once the context is cancelled")
+	}
+}
@@ -40,7 +40,11 @@ func (ps *PodStream) Stream() (io.ReadCloser, error) {
 
 		for _, c := range fl.Containers {
 			if c.Name == ps.opts.Container {
-				log := strings.Join(c.Logs, "\n")
+				logs := c.Logs
+				if tail := ps.opts.TailLines; tail != nil && int(*tail) < len(logs) {
+					logs = logs[len(logs)-int(*tail):]
+				}
+				log := strings.Join(logs, "\n")
 				return ioutil.NopCloser(strings.NewReader(log)), nil
 			}
 		}
@@ -15,6 +15,7 @@
 package pods
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"time"
@@ -50,6 +51,9 @@ type Pod struct {
 	Ns       string
 	Kc       k8s.Interface
 	Streamer stream.NewStreamerFunc
+	// Pending is called with the pod each time it changes while Wait
+	// waits for it to run
+	Pending func(*corev1.Pod)
 }
 
 func New(name, ns string, client k8s.Interface, streamer stream.NewStreamerFunc) *Pod {
@@ -68,47 +72,59 @@ func NewWithDefaults(name, ns string, client k8s.Interface) *Pod {
 	}
 }
 
-//Wait wait for the pod to get up and running
-func (p *Pod) Wait() (*corev1.Pod, error) {
+//Wait wait for the pod to get up and running, it gives up once ctx is done
+func (p *Pod) Wait(ctx context.Context) (*corev1.Pod, error) {
 	// ensure pod exists before we actually check for it
 	if _, err := p.Get(); err != nil {
 		return nil, err
 	}
 
-	stopC := make(chan struct{})
-	eventC := make(chan interface{})
-	defer close(eventC)
-	defer close(stopC)
-
-	p.watcher(stopC, eventC)
+	// the informer is stopped along with the context on return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	var pod *corev1.Pod
-	var err error
-	for e := range eventC {
-		pod, err = checkPodStatus(e)
-		if pod != nil || err != nil {
-			break
+	eventC := make(chan interface{})
+	p.watcher(ctx, eventC)
+
+	for {
+		select {
+		case e := <-eventC:
+			pod, err := checkPodStatus(e)
+			if pod != nil || err != nil {
+				return pod, err
+			}
+			if pending, ok := e.(*corev1.Pod); ok && p.Pending != nil {
+				p.Pending(pending)
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
 	}
-
-	return pod, err
 }
 
-func (p *Pod) watcher(stopC <-chan struct{}, eventC chan<- interface{}) {
+func (p *Pod) watcher(ctx context.Context, eventC chan<- interface{}) {
 	factory := informers.NewSharedInformerFactoryWithOptions(
 		p.Kc, time.Second*10,
 		informers.WithNamespace(p.Ns),
 		informers.WithTweakListOptions(podOpts(p.Name)))
 
+	// the events are dropped once nobody waits for them anymore
+	send := func(obj interface{}) {
+		select {
+		case eventC <- obj:
+		case <-ctx.Done():
+		}
+	}
+
 	factory.Core().V1().Pods().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
-			AddFunc:    func(obj interface{}) { eventC <- obj },
-			UpdateFunc: func(oldObj, newObj interface{}) { eventC <- newObj },
-			DeleteFunc: func(obj interface{}) { eventC <- obj },
+			AddFunc:    send,
+			UpdateFunc: func(oldObj, newObj interface{}) { send(newObj) },
+			DeleteFunc: send,
 		})
 
-	factory.Start(stopC)
-	factory.WaitForCacheSync(stopC)
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
 }
 
 func podOpts(name string) func(opts *v1.ListOptions) {
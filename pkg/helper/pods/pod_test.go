@@ -15,6 +15,7 @@
 package pods
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -46,7 +47,7 @@ func Test_wait_pod_initialized(t *testing.T) {
 	kc := simulateAddWatch(t, initial, later)
 
 	pod := NewWithDefaults(podname, ns, kc)
-	p, err := pod.Wait()
+	p, err := pod.Wait(context.Background())
 
 	if p == nil {
 		t.Errorf("Unexpected p mismatch: \n%s\n", p)
@@ -74,7 +75,7 @@ func Test_wait_pod_success(t *testing.T) {
 	kc := simulateAddWatch(t, initial, later)
 
 	pod := NewWithDefaults(podname, ns, kc)
-	p, err := pod.Wait()
+	p, err := pod.Wait(context.Background())
 
 	if p == nil {
 		t.Errorf("Unexpected output mismatch: \n%s\n", p)
@@ -103,7 +104,7 @@ func Test_wait_pod_fail(t *testing.T) {
 	kc := simulateAddWatch(t, initial, later)
 
 	pod := NewWithDefaults(podname, ns, kc)
-	p, err := pod.Wait()
+	p, err := pod.Wait(context.Background())
 
 	if p == nil {
 		t.Errorf("Unexpected output mismatch: \n%s\n", p)
@@ -134,7 +135,7 @@ func Test_wait_pod_imagepull_error(t *testing.T) {
 
 	kc := simulateDeleteWatch(t, initial, later)
 	pod := NewWithDefaults(podname, ns, kc)
-	p, err := pod.Wait()
+	p, err := pod.Wait(context.Background())
 
 	if p == nil {
 		t.Errorf("Unexpected output mismatch: \n%s\n", p)
@@ -178,3 +179,61 @@ func simulateDeleteWatch(t *testing.T, initial *corev1.Pod, later *corev1.Pod) k
 
 	return clients.Kube
 }
+
+func Test_wait_pod_pending(t *testing.T) {
+	podname := "test"
+	ns := "ns"
+
+	initial := tb.Pod(podname, ns,
+		cb.PodStatus(
+			cb.PodPhase(corev1.PodPending),
+		),
+	)
+	initial.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "step-build", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+	}
+	later := tb.Pod(podname, ns,
+		cb.PodStatus(
+			cb.PodPhase(corev1.PodRunning),
+		),
+	)
+	kc := simulateAddWatch(t, initial, later)
+
+	reasons := []string{}
+	pod := NewWithDefaults(podname, ns, kc)
+	pod.Pending = func(p *corev1.Pod) {
+		for _, cs := range p.Status.ContainerStatuses {
+			reasons = append(reasons, cs.State.Waiting.Reason)
+		}
+	}
+
+	if _, err := pod.Wait(context.Background()); err != nil {
+		t.Errorf("Unexpected error: \n%s\n", err)
+	}
+
+	test.AssertOutput(t, []string{"ImagePullBackOff"}, reasons)
+}
+
+func Test_wait_pod_cancelled(t *testing.T) {
+	podname := "test"
+	ns := "ns"
+
+	pending := tb.Pod(podname, ns,
+		cb.PodStatus(
+			cb.PodPhase(corev1.PodPending),
+		),
+	)
+	clients, _ := test.SeedTestData(t, pipelinetest.Data{Pods: []*corev1.Pod{pending}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	pod := NewWithDefaults(podname, ns, clients.Kube)
+	p, err := pod.Wait(ctx)
+
+	if p != nil {
+		t.Errorf("Unexpected pod: \n%v\n", p)
+	}
+
+	test.AssertOutput(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,87 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results
+
+import (
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// Result is a result of a resource of a task, like the digest of the image
+// it built
+type Result struct {
+	Task     string `json:"task,omitempty"`
+	TaskRun  string `json:"taskRun,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// TaskRun returns the results of the resources of the taskrun with status s
+func TaskRun(s *v1alpha1.TaskRunStatus) []Result {
+	results := []Result{}
+	for _, r := range s.ResourcesResult {
+		res := Result{
+			Resource: r.ResourceRef.Name,
+			Key:      r.Key,
+			Value:    r.Value,
+		}
+
+		// the results written before Key and Value replaced them only have
+		// a Name and a Digest
+		if res.Resource == "" {
+			res.Resource = r.Name
+		}
+		if res.Key == "" && r.Digest != "" {
+			res.Key = "digest"
+			res.Value = r.Digest
+		}
+
+		results = append(results, res)
+	}
+	return results
+}
+
+// PipelineRun returns the results of the resources of all the taskruns of
+// the pipelinerun, sorted by task
+func PipelineRun(pr *v1alpha1.PipelineRun) []Result {
+	names := []string{}
+	for name := range pr.Status.TaskRuns {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ti, tj := pr.Status.TaskRuns[names[i]], pr.Status.TaskRuns[names[j]]
+		if ti.PipelineTaskName != tj.PipelineTaskName {
+			return ti.PipelineTaskName < tj.PipelineTaskName
+		}
+		return names[i] < names[j]
+	})
+
+	results := []Result{}
+	for _, name := range names {
+		trs := pr.Status.TaskRuns[name]
+		if trs.Status == nil {
+			continue
+		}
+
+		for _, r := range TaskRun(trs.Status) {
+			r.Task = trs.PipelineTaskName
+			r.TaskRun = name
+			results = append(results, r)
+		}
+	}
+	return results
+}
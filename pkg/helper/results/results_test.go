@@ -0,0 +1,69 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results
+
+import (
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestTaskRun(t *testing.T) {
+	s := &v1alpha1.TaskRunStatus{
+		ResourcesResult: []v1alpha1.PipelineResourceResult{
+			{Key: "digest", Value: "sha256:1234", ResourceRef: v1alpha1.PipelineResourceRef{Name: "app-image"}},
+			// written before Key and Value were added
+			{Name: "base-image", Digest: "sha256:5678"},
+		},
+	}
+
+	test.AssertOutput(t, []Result{
+		{Resource: "app-image", Key: "digest", Value: "sha256:1234"},
+		{Resource: "base-image", Key: "digest", Value: "sha256:5678"},
+	}, TaskRun(s))
+}
+
+func TestPipelineRun(t *testing.T) {
+	result := func(resource, digest string) *v1alpha1.TaskRunStatus {
+		return &v1alpha1.TaskRunStatus{
+			ResourcesResult: []v1alpha1.PipelineResourceResult{
+				{Key: "digest", Value: digest, ResourceRef: v1alpha1.PipelineResourceRef{Name: resource}},
+			},
+		}
+	}
+
+	pr := tb.PipelineRun("release", "ns",
+		tb.PipelineRunStatus(
+			tb.PipelineRunTaskRunsStatus("release-push-x7k2", &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "push",
+				Status:           result("app-image", "sha256:1234"),
+			}),
+			tb.PipelineRunTaskRunsStatus("release-build-a9f3", &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "build",
+				Status:           result("base-image", "sha256:5678"),
+			}),
+			tb.PipelineRunTaskRunsStatus("release-test-b1c2", &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "test",
+			}),
+		),
+	)
+
+	test.AssertOutput(t, []Result{
+		{Task: "build", TaskRun: "release-build-a9f3", Resource: "base-image", Key: "digest", Value: "sha256:5678"},
+		{Task: "push", TaskRun: "release-push-x7k2", Resource: "app-image", Key: "digest", Value: "sha256:1234"},
+	}, PipelineRun(pr))
+}
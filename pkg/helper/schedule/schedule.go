@@ -0,0 +1,372 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LabelKey marks the objects of a schedule, its value is the name of
+	// the schedule
+	LabelKey = "cli.tekton.dev/schedule"
+	// DefaultImage runs kubectl to create the PipelineRun
+	DefaultImage = "bitnami/kubectl:1.18.3"
+
+	pipelineLabel = "tekton.dev/pipeline"
+	runKey        = "pipelinerun.yaml"
+	runDir        = "/etc/tkn-schedule"
+)
+
+// Schedule creates a PipelineRun of a pipeline periodically
+type Schedule struct {
+	Name      string
+	Namespace string
+	Pipeline  string
+	Cron      string
+	Image     string
+	Run       *v1alpha1.PipelineRun
+}
+
+func (s *Schedule) meta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Labels: map[string]string{
+			LabelKey:      s.Name,
+			pipelineLabel: s.Pipeline,
+		},
+	}
+}
+
+// Objects returns the ConfigMap holding the PipelineRun, the
+// ServiceAccount, Role and RoleBinding allowing to create it, and the
+// CronJob creating it
+func (s *Schedule) Objects() ([]runtime.Object, error) {
+	run := s.Run.DeepCopy()
+	run.TypeMeta = metav1.TypeMeta{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun"}
+	run.Namespace = ""
+	if run.Labels == nil {
+		run.Labels = map[string]string{}
+	}
+	run.Labels[LabelKey] = s.Name
+
+	data, err := yaml.Marshal(run)
+	if err != nil {
+		return nil, err
+	}
+
+	image := s.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: s.meta(),
+		Data:       map[string]string{runKey: string(data)},
+	}
+
+	sa := &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: s.meta(),
+	}
+
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+		ObjectMeta: s.meta(),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"tekton.dev"},
+			Resources: []string{"pipelineruns"},
+			Verbs:     []string{"create"},
+		}},
+	}
+
+	binding := &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+		ObjectMeta: s.meta(),
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      s.Name,
+			Namespace: s.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     s.Name,
+		},
+	}
+
+	cj := &batchv1beta1.CronJob{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1beta1", Kind: "CronJob"},
+		ObjectMeta: s.meta(),
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          s.Cron,
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: s.meta().Labels},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: s.meta().Labels},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: s.Name,
+							RestartPolicy:      corev1.RestartPolicyOnFailure,
+							Containers: []corev1.Container{{
+								Name:    "create-pipelinerun",
+								Image:   image,
+								Command: []string{"kubectl", "create", "-f", runDir + "/" + runKey},
+								VolumeMounts: []corev1.VolumeMount{
+									{Name: "pipelinerun", MountPath: runDir},
+								},
+							}},
+							Volumes: []corev1.Volume{{
+								Name: "pipelinerun",
+								VolumeSource: corev1.VolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: s.Name},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return []runtime.Object{cm, sa, role, binding, cj}, nil
+}
+
+// Create creates the objects of the schedule, removing the ones already
+// created when one of them fails
+func (s *Schedule) Create(kube k8s.Interface) (err error) {
+	objs, err := s.Objects()
+	if err != nil {
+		return err
+	}
+
+	created := []func() error{}
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(created) - 1; i >= 0; i-- {
+			_ = created[i]()
+		}
+	}()
+
+	ns := s.Namespace
+	opts := &metav1.DeleteOptions{}
+	for _, obj := range objs {
+		var del func() error
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			_, err = kube.CoreV1().ConfigMaps(ns).Create(o)
+			del = func() error { return kube.CoreV1().ConfigMaps(ns).Delete(o.Name, opts) }
+		case *corev1.ServiceAccount:
+			_, err = kube.CoreV1().ServiceAccounts(ns).Create(o)
+			del = func() error { return kube.CoreV1().ServiceAccounts(ns).Delete(o.Name, opts) }
+		case *rbacv1.Role:
+			_, err = kube.RbacV1().Roles(ns).Create(o)
+			del = func() error { return kube.RbacV1().Roles(ns).Delete(o.Name, opts) }
+		case *rbacv1.RoleBinding:
+			_, err = kube.RbacV1().RoleBindings(ns).Create(o)
+			del = func() error { return kube.RbacV1().RoleBindings(ns).Delete(o.Name, opts) }
+		case *batchv1beta1.CronJob:
+			_, err = kube.BatchV1beta1().CronJobs(ns).Create(o)
+			del = func() error { return kube.BatchV1beta1().CronJobs(ns).Delete(o.Name, opts) }
+		}
+		if err != nil {
+			kind := obj.GetObjectKind().GroupVersionKind().Kind
+			return fmt.Errorf("failed to create %s %s: %s", kind, s.Name, err)
+		}
+		created = append(created, del)
+	}
+	return nil
+}
+
+// List returns the CronJobs of the schedules in namespace ns, restricted
+// to the schedules of pipeline when it is not empty
+func List(kube k8s.Interface, ns, pipeline string) ([]batchv1beta1.CronJob, error) {
+	selector := LabelKey
+	if pipeline != "" {
+		selector += fmt.Sprintf(",%s=%s", pipelineLabel, pipeline)
+	}
+
+	cjs, err := kube.BatchV1beta1().CronJobs(ns).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return cjs.Items, nil
+}
+
+// Delete removes the objects of the schedule name
+func Delete(kube k8s.Interface, ns, name string) error {
+	cj, err := kube.BatchV1beta1().CronJobs(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if _, ok := cj.Labels[LabelKey]; !ok {
+		return fmt.Errorf("cronjob %s is not a pipeline schedule", name)
+	}
+
+	opts := &metav1.DeleteOptions{}
+	for _, del := range []func() error{
+		func() error { return kube.BatchV1beta1().CronJobs(ns).Delete(name, opts) },
+		func() error { return kube.RbacV1().RoleBindings(ns).Delete(name, opts) },
+		func() error { return kube.RbacV1().Roles(ns).Delete(name, opts) },
+		func() error { return kube.CoreV1().ServiceAccounts(ns).Delete(name, opts) },
+		func() error { return kube.CoreV1().ConfigMaps(ns).Delete(name, opts) },
+	} {
+		if err := del(); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pipeline returns the name of the pipeline the CronJob schedules
+func Pipeline(cj *batchv1beta1.CronJob) string {
+	return cj.Labels[pipelineLabel]
+}
+
+// ValidateCron checks that expr is a cron schedule of five fields, minute
+// hour day-of-month month day-of-week, or one of the predefined @
+// schedules, as accepted by the CronJob controller
+func ValidateCron(expr string) error {
+	if strings.HasPrefix(expr, "@") {
+		return validateDescriptor(expr)
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return fmt.Errorf("invalid cron schedule %q, expected five fields: minute hour day-of-month month day-of-week", expr)
+	}
+	for i, f := range fields {
+		if err := cronFields[i].validate(f); err != nil {
+			return fmt.Errorf("invalid cron schedule %q: %s", expr, err)
+		}
+	}
+	return nil
+}
+
+var descriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+func validateDescriptor(expr string) error {
+	if descriptors[expr] {
+		return nil
+	}
+	if d := strings.TrimPrefix(expr, "@every "); d != expr {
+		if _, err := time.ParseDuration(strings.TrimSpace(d)); err != nil {
+			return fmt.Errorf("invalid cron schedule %q: %s", expr, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid cron schedule %q, unknown descriptor", expr)
+}
+
+// cronField is the range of the values of a field of a cron schedule and
+// the names which can be used instead of them
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day-of-month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day-of-week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// validate checks a comma separated list of *, ? or value ranges, each
+// optionally followed by a /step
+func (f cronField) validate(expr string) error {
+	for _, item := range strings.Split(expr, ",") {
+		rng, step := item, ""
+		if i := strings.Index(item, "/"); i >= 0 {
+			rng, step = item[:i], item[i+1:]
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q in %s field", step, f.name)
+			}
+		}
+
+		if rng == "*" || rng == "?" {
+			continue
+		}
+
+		bounds := strings.Split(rng, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid range %q in %s field", rng, f.name)
+		}
+		low, err := f.value(bounds[0])
+		if err != nil {
+			return err
+		}
+		if len(bounds) == 2 {
+			high, err := f.value(bounds[1])
+			if err != nil {
+				return err
+			}
+			if low > high {
+				return fmt.Errorf("invalid range %q in %s field, %d is beyond %d", rng, f.name, low, high)
+			}
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
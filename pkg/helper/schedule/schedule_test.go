@@ -0,0 +1,138 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/cli/pkg/test"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stest "k8s.io/client-go/testing"
+)
+
+func TestValidateCron(t *testing.T) {
+	for _, expr := range []string{
+		"0 2 * * *", "*/5 * * * 1-5", "@hourly", "@every 1h30m",
+		"0,30 8-18/2 1 JAN-jun ?", "59 23 31 12 sat",
+	} {
+		if err := ValidateCron(expr); err != nil {
+			t.Errorf("Unexpected error for %q: %v", expr, err)
+		}
+	}
+	for _, expr := range []string{
+		"", "0 2 * *", "0 0 2 * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 7", "a b c d e", "*/0 * * * *", "5-1 * * * *",
+		"1-2-3 * * * *", ",* * * * *", "@fortnightly", "@every soon",
+	} {
+		if err := ValidateCron(expr); err == nil {
+			t.Errorf("Expected an error for %q", expr)
+		}
+	}
+}
+
+func TestCreate_rollback(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	kube.PrependReactor("create", "cronjobs", func(action k8stest.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("quota exceeded")
+	})
+
+	s := &Schedule{
+		Name:      "nightly",
+		Namespace: "ns",
+		Pipeline:  "build",
+		Cron:      "@daily",
+		Run:       &v1alpha1.PipelineRun{},
+	}
+	err := s.Create(kube)
+	if err == nil {
+		t.Fatal("Expected an error creating the cronjob")
+	}
+	test.AssertOutput(t, "failed to create CronJob nightly: quota exceeded", err.Error())
+
+	for _, get := range []func() error{
+		func() error {
+			_, err := kube.CoreV1().ConfigMaps("ns").Get("nightly", metav1.GetOptions{})
+			return err
+		},
+		func() error {
+			_, err := kube.CoreV1().ServiceAccounts("ns").Get("nightly", metav1.GetOptions{})
+			return err
+		},
+		func() error {
+			_, err := kube.RbacV1().Roles("ns").Get("nightly", metav1.GetOptions{})
+			return err
+		},
+		func() error {
+			_, err := kube.RbacV1().RoleBindings("ns").Get("nightly", metav1.GetOptions{})
+			return err
+		},
+	} {
+		if err := get(); err == nil {
+			t.Error("Expected the objects created before the failure to be deleted")
+		}
+	}
+}
+
+func TestCreateListDelete(t *testing.T) {
+	kube := fake.NewSimpleClientset(&batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+	})
+
+	s := &Schedule{
+		Name:      "nightly",
+		Namespace: "ns",
+		Pipeline:  "build",
+		Cron:      "@daily",
+		Run: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "build-run-", Namespace: "ns"},
+			Spec:       v1alpha1.PipelineRunSpec{PipelineRef: &v1alpha1.PipelineRef{Name: "build"}},
+		},
+	}
+	if err := s.Create(kube); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cjs, err := List(kube, "ns", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 1, len(cjs))
+	test.AssertOutput(t, "build", Pipeline(&cjs[0]))
+	test.AssertOutput(t, DefaultImage, cjs[0].Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image)
+
+	cjs, err = List(kube, "ns", "other")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 0, len(cjs))
+
+	err = Delete(kube, "ns", "backup")
+	if err == nil {
+		t.Fatal("Expected an error deleting a cronjob not created by a schedule")
+	}
+	test.AssertOutput(t, "cronjob backup is not a pipeline schedule", err.Error())
+
+	if err := Delete(kube, "ns", "nightly"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := kube.RbacV1().Roles("ns").Get("nightly", metav1.GetOptions{}); err == nil {
+		t.Error("Expected the role of the schedule to be deleted")
+	}
+}
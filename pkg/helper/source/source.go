@@ -0,0 +1,371 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/cli/pkg/helper/git"
+	"github.com/tektoncd/cli/pkg/helper/pods"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// MaxArchiveSize is the largest compressed source tree which fits in
+	// a ConfigMap
+	MaxArchiveSize = 1000 * 1024
+
+	archiveKey = "source.tar.gz"
+	gitPort    = 9418
+	// Image runs the git daemon serving the uploaded source
+	Image = "alpine/git:v2.26.2"
+	// Revision is the branch the uploaded source is committed to
+	Revision = "master"
+
+	// Lifetime is how long the git daemon serves the source, the run
+	// using it has to clone it by then. The pod is then stopped so that it
+	// doesn't hold resources for as long as the run object exists.
+	Lifetime = time.Hour
+
+	// readyTimeout is how long the git daemon has to start listening once
+	// the pod runs
+	readyTimeout = 2 * time.Minute
+
+	labelKey = "cli.tekton.dev/source-upload"
+
+	serveScript = `set -e
+mkdir -p /srv/source && cd /srv/source
+tar xzf /upload/` + archiveKey + `
+git init -q
+git symbolic-ref HEAD refs/heads/` + Revision + `
+git add -A
+git -c user.name=tkn -c user.email=tkn@localhost commit -q --allow-empty -m "tkn source upload"
+touch /srv/source/.git/git-daemon-export-ok
+exec git daemon --reuseaddr --base-path=/srv --port=9418`
+)
+
+// Archive packages dir as a gzipped tarball. When dir is a git working
+// copy, the files ignored by git are left out.
+func Archive(dir string) ([]byte, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range files {
+		if err := addFile(tw, dir, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func listFiles(dir string) ([]string, error) {
+	if repo, err := git.Local(dir); err == nil {
+		return repo.Files()
+	}
+
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+func addFile(tw *tar.Writer, dir, name string) error {
+	path := filepath.Join(dir, name)
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		// tracked by git but deleted from the working copy
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	} else if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(name)
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if link != "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// UploadDir archives dir and stages it in namespace ns, telling on out
+// where it is served
+func UploadDir(out io.Writer, kube k8s.Interface, ns, dir string) (*Upload, error) {
+	archive, err := Archive(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %s", dir, err)
+	}
+
+	upload, err := Stage(kube, ns, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(out, "Source uploaded from %s to %s\n", dir, upload.URL())
+	return upload, nil
+}
+
+// Upload is a source tree staged in the cluster and served over the git
+// protocol by a helper pod
+type Upload struct {
+	Name string
+	Ns   string
+	kube k8s.Interface
+}
+
+// Stage stores archive in a ConfigMap and starts the helper pod and
+// service serving it, waiting until the git daemon is ready. The objects
+// already created are deleted when it fails.
+func Stage(kube k8s.Interface, ns string, archive []byte) (_ *Upload, err error) {
+	if len(archive) > MaxArchiveSize {
+		return nil, fmt.Errorf("source archive is %d bytes, which exceeds the %d bytes that can be uploaded, push the changes and use a git resource instead", len(archive), MaxArchiveSize)
+	}
+
+	u := &Upload{
+		Name: "tkn-source-" + rand.String(5),
+		Ns:   ns,
+		kube: kube,
+	}
+	labels := map[string]string{labelKey: u.Name}
+
+	defer func() {
+		if err != nil {
+			_ = u.Delete()
+		}
+	}()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: u.Name, Namespace: ns, Labels: labels},
+		BinaryData: map[string][]byte{archiveKey: archive},
+	}
+	if _, err := kube.CoreV1().ConfigMaps(ns).Create(cm); err != nil {
+		return nil, fmt.Errorf("failed to upload source: %s", err)
+	}
+
+	deadline := int64(Lifetime / time.Second)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: u.Name, Namespace: ns, Labels: labels},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			Containers: []corev1.Container{{
+				Name:    "git-daemon",
+				Image:   Image,
+				Command: []string{"/bin/sh", "-c", serveScript},
+				Ports:   []corev1.ContainerPort{{Name: "git", ContainerPort: gitPort}},
+				// the service only routes to the pod once the daemon listens
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(gitPort)},
+					},
+					PeriodSeconds: 1,
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "upload", MountPath: "/upload"},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "upload",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: u.Name},
+					},
+				},
+			}},
+		},
+	}
+	if _, err := kube.CoreV1().Pods(ns).Create(pod); err != nil {
+		return nil, fmt.Errorf("failed to start source pod: %s", err)
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: u.Name, Namespace: ns, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "git",
+				Port:       gitPort,
+				TargetPort: intstr.FromInt(gitPort),
+			}},
+		},
+	}
+	if _, err := kube.CoreV1().Services(ns).Create(svc); err != nil {
+		return nil, fmt.Errorf("failed to expose source pod: %s", err)
+	}
+
+	if err := u.waitReady(); err != nil {
+		return nil, fmt.Errorf("source pod %s failed to start: %s", u.Name, err)
+	}
+
+	return u, nil
+}
+
+// waitReady waits until the pod runs and its git daemon accepts connections
+func (u *Upload) waitReady() error {
+	pod, err := pods.NewWithDefaults(u.Name, u.Ns, u.kube).Wait(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return wait.PollImmediate(time.Second, readyTimeout, func() (bool, error) {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return false, fmt.Errorf("git daemon exited")
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+
+		pod, err = u.kube.CoreV1().Pods(u.Ns).Get(u.Name, metav1.GetOptions{})
+		return false, err
+	})
+}
+
+// URL returns the git url of the uploaded source
+func (u *Upload) URL() string {
+	return fmt.Sprintf("git://%s.%s.svc:%d/source", u.Name, u.Ns, gitPort)
+}
+
+// Spec returns the inline git PipelineResource spec fetching the
+// uploaded source
+func (u *Upload) Spec() *v1alpha1.PipelineResourceSpec {
+	return &v1alpha1.PipelineResourceSpec{
+		Type: v1alpha1.PipelineResourceTypeGit,
+		Params: []v1alpha1.ResourceParam{
+			{Name: "url", Value: u.URL()},
+			{Name: "revision", Value: Revision},
+		},
+	}
+}
+
+// SetOwner makes owner own the staged objects, so that they are garbage
+// collected along with it. The pod stops serving the source after
+// Lifetime anyway.
+func (u *Upload) SetOwner(owner metav1.OwnerReference) error {
+	core := u.kube.CoreV1()
+
+	cm, err := core.ConfigMaps(u.Ns).Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cm.OwnerReferences = append(cm.OwnerReferences, owner)
+	if _, err := core.ConfigMaps(u.Ns).Update(cm); err != nil {
+		return err
+	}
+
+	pod, err := core.Pods(u.Ns).Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	pod.OwnerReferences = append(pod.OwnerReferences, owner)
+	if _, err := core.Pods(u.Ns).Update(pod); err != nil {
+		return err
+	}
+
+	svc, err := core.Services(u.Ns).Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	svc.OwnerReferences = append(svc.OwnerReferences, owner)
+	_, err = core.Services(u.Ns).Update(svc)
+	return err
+}
+
+// Delete removes the staged objects, the ones which were not created being
+// skipped
+func (u *Upload) Delete() error {
+	core := u.kube.CoreV1()
+	deletes := []func(string, *metav1.DeleteOptions) error{
+		core.Services(u.Ns).Delete,
+		core.Pods(u.Ns).Delete,
+		core.ConfigMaps(u.Ns).Delete,
+	}
+
+	var first error
+	for _, del := range deletes {
+		if err := del(u.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,215 @@
+// Copyright © 2019 The Tekton Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/cli/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stest "k8s.io/client-go/testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "tkn-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func archivedFiles(t *testing.T, archive []byte) []string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+
+	names := []string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestArchive(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"main.go":        "package main",
+		"pkg/lib.go":     "package pkg",
+		".git/config":    "[core]",
+		"docs/README.md": "docs",
+	})
+	defer os.RemoveAll(dir)
+
+	archive, err := Archive(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, []string{"docs/README.md", "main.go", "pkg/lib.go"}, archivedFiles(t, archive))
+}
+
+func TestArchive_gitignore(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		".gitignore":    "bin/\n*.log\n",
+		"main.go":       "package main",
+		"bin/app":       "binary",
+		"build.log":     "log",
+		"pkg/lib.go":    "package pkg",
+		"pkg/debug.log": "log",
+	})
+	defer os.RemoveAll(dir)
+
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %s", out)
+	}
+
+	archive, err := Archive(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, []string{".gitignore", "main.go", "pkg/lib.go"}, archivedFiles(t, archive))
+}
+
+func TestStage(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	watcher := watch.NewFake()
+	kube.PrependWatchReactor("pods", k8stest.DefaultWatchReactor(watcher, nil))
+	kube.PrependReactor("create", "pods", func(action k8stest.Action) (bool, runtime.Object, error) {
+		created := action.(k8stest.CreateAction).GetObject().(*corev1.Pod)
+		created.Status.Phase = corev1.PodRunning
+		created.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}
+		pod := created.DeepCopy()
+		go func() {
+			time.Sleep(time.Second)
+			watcher.Modify(pod)
+		}()
+		return false, nil, nil
+	})
+
+	u, err := Stage(kube, "ns", []byte("archive"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pod, err := kube.CoreV1().Pods("ns").Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	probe := pod.Spec.Containers[0].ReadinessProbe
+	if probe == nil || probe.TCPSocket == nil || probe.TCPSocket.Port.IntValue() != gitPort {
+		t.Errorf("Expected a readiness probe on the git port, got %v", probe)
+	}
+	if d := pod.Spec.ActiveDeadlineSeconds; d == nil || *d != 3600 {
+		t.Errorf("Expected the pod to be stopped after an hour, got %v", d)
+	}
+
+	if !strings.HasPrefix(u.Name, "tkn-source-") {
+		t.Errorf("Unexpected name %s", u.Name)
+	}
+	test.AssertOutput(t, "git://"+u.Name+".ns.svc:9418/source", u.Spec().Params[0].Value)
+
+	cm, err := kube.CoreV1().ConfigMaps("ns").Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, []byte("archive"), cm.BinaryData[archiveKey])
+
+	owner := metav1.OwnerReference{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun", Name: "run", UID: "uid"}
+	if err := u.SetOwner(owner); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	svc, err := kube.CoreV1().Services("ns").Get(u.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, []metav1.OwnerReference{owner}, svc.OwnerReferences)
+}
+
+func TestStage_too_large(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+
+	_, err := Stage(kube, "ns", make([]byte, MaxArchiveSize+1))
+	if err == nil {
+		t.Fatal("Expected an error for an oversized archive")
+	}
+	if !strings.Contains(err.Error(), "push the changes and use a git resource instead") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestStage_cleanup(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	kube.PrependReactor("create", "services", func(action k8stest.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("quota exceeded")
+	})
+
+	_, err := Stage(kube, "ns", []byte("archive"))
+	if err == nil {
+		t.Fatal("Expected an error when the service cannot be created")
+	}
+	test.AssertOutput(t, "failed to expose source pod: quota exceeded", err.Error())
+
+	cms, err := kube.CoreV1().ConfigMaps("ns").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 0, len(cms.Items))
+
+	pods, err := kube.CoreV1().Pods("ns").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, 0, len(pods.Items))
+}
@@ -37,6 +37,9 @@ func LastRun(tekton versioned.Interface, task string, ns string) (*v1alpha1.Task
 	}
 
 	if len(runs.Items) == 0 {
+		if task == "" {
+			return nil, fmt.Errorf("no taskruns found in namespace %s", ns)
+		}
 		return nil, fmt.Errorf("no taskruns related to task %s found in namespace %s", task, ns)
 	}
 
@@ -15,8 +15,11 @@
 package taskrun
 
 import (
+	"sort"
+
 	"github.com/tektoncd/cli/pkg/cli"
 	"github.com/tektoncd/cli/pkg/cmd/taskrun"
+	"github.com/tektoncd/cli/pkg/helper/pods"
 	"github.com/tektoncd/cli/pkg/helper/pods/stream"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
@@ -24,6 +27,9 @@ import (
 type Run struct {
 	Name string
 	Task string
+	// Condition is the name of the condition when the run is a check of
+	// one of the conditions of the task
+	Condition string
 }
 
 //NewLogReader returns the new instance of LogReader for
@@ -31,17 +37,19 @@ type Run struct {
 func (t *Run) NewLogReader(ns string, clientSet *cli.Clients,
 	streamer stream.NewStreamerFunc,
 	num int, follow bool,
-	allSteps bool) *taskrun.LogReader {
+	allSteps bool, opts pods.LogOptions) *taskrun.LogReader {
 
 	return &taskrun.LogReader{
-		Run:      t.Name,
-		Task:     t.Task,
-		Number:   num,
-		Ns:       ns,
-		Clients:  clientSet,
-		Streamer: streamer,
-		Follow:   follow,
-		AllSteps: allSteps,
+		Run:        t.Name,
+		Task:       t.Task,
+		Number:     num,
+		Ns:         ns,
+		Clients:    clientSet,
+		Streamer:   streamer,
+		Follow:     follow,
+		AllSteps:   allSteps,
+		LogOptions: opts,
+		Condition:  t.Condition,
 	}
 }
 
@@ -88,13 +96,61 @@ func SortTasksBySpecOrder(pipelineTasks []v1alpha1.PipelineTask, pipelinesTaskRu
 
 	trs := []Run{}
 	for _, ts := range pipelineTasks {
-		if n, ok := trNames[ts.Name]; ok {
-			trs = append(trs, Run{
-				Task: ts.Name,
-				Name: n,
-			})
+		n, ok := trNames[ts.Name]
+		if !ok {
+			continue
 		}
+
+		status := pipelinesTaskRuns[n]
+		trs = append(trs, conditionChecks(ts, status)...)
+
+		// the task has not run when its conditions are not met
+		if status.Status == nil && len(status.ConditionChecks) > 0 {
+			continue
+		}
+
+		trs = append(trs, Run{
+			Task: ts.Name,
+			Name: n,
+		})
 	}
 
 	return trs
 }
+
+// conditionChecks returns the runs of the checks of the conditions of the
+// task, in the order of the conditions in the spec of the task
+func conditionChecks(pt v1alpha1.PipelineTask, status *v1alpha1.PipelineRunTaskRunStatus) []Run {
+	order := map[string]int{}
+	for i, c := range pt.Conditions {
+		order[c.ConditionRef] = i
+	}
+
+	checks := []Run{}
+	for name, cc := range status.ConditionChecks {
+		checks = append(checks, Run{
+			Name:      name,
+			Task:      pt.Name,
+			Condition: cc.ConditionName,
+		})
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		oi, oj := order[checks[i].Condition], order[checks[j].Condition]
+		if oi != oj {
+			return oi < oj
+		}
+		return checks[i].Name < checks[j].Name
+	})
+
+	return checks
+}
+
+// HasConditionCheckScheduled tells whether the pod of the condition check
+// has been created
+func HasConditionCheckScheduled(cc *v1alpha1.PipelineRunConditionCheckStatus) bool {
+	if cc.Status != nil {
+		return cc.Status.PodName != ""
+	}
+	return false
+}
@@ -29,6 +29,7 @@ type Params struct {
 	Kube                  k8s.Interface
 	Clock                 clockwork.Clock
 	Cls                   *cli.Clients
+	User                  string
 }
 
 var _ cli.Params = &Params{}
@@ -67,6 +68,10 @@ func (p *Params) KubeClient() (k8s.Interface, error) {
 	return p.Kube, nil
 }
 
+func (p *Params) KubeUser() string {
+	return p.User
+}
+
 func (p *Params) Clients() (*cli.Clients, error) {
 	if p.Cls != nil {
 		return p.Cls, nil